@@ -2,6 +2,8 @@ package config
 
 import (
 	"log"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/memsbdm/restaurant-api/pkg/env"
@@ -19,7 +21,9 @@ type (
 		Cache    *Cache
 		DB       *DB
 		Google   *Google
+		Jobs     *Jobs
 		Mailer   *Mailer
+		OIDC     *OIDC
 		Security *Security
 		Server   *Server
 	}
@@ -50,6 +54,15 @@ type (
 		APIKey string
 	}
 
+	Jobs struct {
+		PlaceSyncInterval          time.Duration
+		PlaceSyncMaxAge            time.Duration
+		PlaceSyncBatchSize         int
+		AccountDeletionInterval    time.Duration
+		AccountDeletionGracePeriod time.Duration
+		AccountDeletionBatchSize   int
+	}
+
 	Mailer struct {
 		Region    string
 		AccessKey string
@@ -58,13 +71,34 @@ type (
 		DebugTo   string
 	}
 
+	// OIDC configures sign in with Google, the endpoints can point to another issuer for local testing
+	OIDC struct {
+		ClientID     string
+		ClientSecret string
+		RedirectURL  string
+		Issuer       string
+		AuthURL      string
+		TokenURL     string
+		JWKSURL      string
+	}
+
 	Security struct {
-		OATSecret []byte
-		SPTSecret []byte
+		OATSecret                  []byte
+		SPTSecret                  []byte
+		RecoveryCodeSecret         []byte
+		RefreshTokenDuration       time.Duration
+		RefreshTokenFamilyDuration time.Duration
+		LoginMaxAttempts           int
+		LoginMaxAttemptsPerIP      int
+		LoginAttemptWindow         time.Duration
+		LoginLockoutDuration       time.Duration
+		LoginMaxLockoutDuration    time.Duration
 	}
 
 	Server struct {
 		Port int
+		// TrustedProxies are the networks whose X-Forwarded-For hops are trusted to resolve the client IP
+		TrustedProxies []netip.Prefix
 	}
 )
 
@@ -95,6 +129,15 @@ func New() *Container {
 		APIKey: env.GetString("GOOGLE_API_KEY"),
 	}
 
+	jobs := &Jobs{
+		PlaceSyncInterval:          env.GetOptionalDuration("PLACE_SYNC_INTERVAL", time.Hour),
+		PlaceSyncMaxAge:            env.GetOptionalDuration("PLACE_SYNC_MAX_AGE", 7*24*time.Hour),
+		PlaceSyncBatchSize:         env.GetOptionalInt("PLACE_SYNC_BATCH_SIZE", 50),
+		AccountDeletionInterval:    env.GetOptionalDuration("ACCOUNT_DELETION_INTERVAL", time.Hour),
+		AccountDeletionGracePeriod: env.GetOptionalDuration("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+		AccountDeletionBatchSize:   env.GetOptionalInt("ACCOUNT_DELETION_BATCH_SIZE", 50),
+	}
+
 	mailer := &Mailer{
 		Region:    env.GetString("MAILER_REGION"),
 		AccessKey: env.GetString("MAILER_ACCESS_KEY"),
@@ -103,13 +146,32 @@ func New() *Container {
 		DebugTo:   env.GetString("MAILER_DEBUG_TO"),
 	}
 
+	oidc := &OIDC{
+		ClientID:     env.GetOptionalString("OIDC_CLIENT_ID", ""),
+		ClientSecret: env.GetOptionalString("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:  env.GetOptionalString("OIDC_REDIRECT_URL", app.Host+"/api/v1/auth/google/callback"),
+		Issuer:       env.GetOptionalString("OIDC_ISSUER", "https://accounts.google.com"),
+		AuthURL:      env.GetOptionalString("OIDC_AUTH_URL", "https://accounts.google.com/o/oauth2/v2/auth"),
+		TokenURL:     env.GetOptionalString("OIDC_TOKEN_URL", "https://oauth2.googleapis.com/token"),
+		JWKSURL:      env.GetOptionalString("OIDC_JWKS_URL", "https://www.googleapis.com/oauth2/v3/certs"),
+	}
+
 	security := &Security{
-		OATSecret: env.GetBytes("OAT_SECRET"),
-		SPTSecret: env.GetBytes("SPT_SECRET"),
+		OATSecret:                  env.GetBytes("OAT_SECRET"),
+		SPTSecret:                  env.GetBytes("SPT_SECRET"),
+		RecoveryCodeSecret:         env.GetBytes("RECOVERY_CODE_SECRET"),
+		RefreshTokenDuration:       env.GetOptionalDuration("REFRESH_TOKEN_DURATION", 30*24*time.Hour),
+		RefreshTokenFamilyDuration: env.GetOptionalDuration("REFRESH_TOKEN_FAMILY_DURATION", 90*24*time.Hour),
+		LoginMaxAttempts:           env.GetOptionalInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginMaxAttemptsPerIP:      env.GetOptionalInt("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+		LoginAttemptWindow:         env.GetOptionalDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+		LoginLockoutDuration:       env.GetOptionalDuration("LOGIN_LOCKOUT_DURATION", time.Minute),
+		LoginMaxLockoutDuration:    env.GetOptionalDuration("LOGIN_MAX_LOCKOUT_DURATION", time.Hour),
 	}
 
 	server := &Server{
-		Port: env.GetOptionalInt("PORT", 8080),
+		Port:           env.GetOptionalInt("PORT", 8080),
+		TrustedProxies: parseTrustedProxies(env.GetOptionalString("TRUSTED_PROXIES", "")),
 	}
 
 	c := &Container{
@@ -117,7 +179,9 @@ func New() *Container {
 		Cache:    cache,
 		DB:       db,
 		Google:   google,
+		Jobs:     jobs,
 		Mailer:   mailer,
+		OIDC:     oidc,
 		Security: security,
 		Server:   server,
 	}
@@ -125,6 +189,32 @@ func New() *Container {
 	return c.Validate()
 }
 
+// parseTrustedProxies reads a comma separated list of CIDRs or single IPs.
+func parseTrustedProxies(val string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(val, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				log.Fatalf("env variable TRUSTED_PROXIES is incorrect, got: %s", entry)
+			}
+			entry = netip.PrefixFrom(addr, addr.BitLen()).String()
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			log.Fatalf("env variable TRUSTED_PROXIES is incorrect, got: %s", entry)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
 func (c *Container) Validate() *Container {
 	if c.App.Env != EnvProduction && c.App.Env != EnvStaging && c.App.Env != EnvDev {
 		log.Fatalf("env variable ENVIRONMENT is incorrect, got: %s", c.App.Env)
@@ -1,10 +1,13 @@
 package app
 
 import (
+	"context"
+
 	"github.com/memsbdm/restaurant-api/config"
 	"github.com/memsbdm/restaurant-api/internal/cache"
 	"github.com/memsbdm/restaurant-api/internal/database"
 	"github.com/memsbdm/restaurant-api/internal/handler"
+	"github.com/memsbdm/restaurant-api/internal/job"
 	"github.com/memsbdm/restaurant-api/internal/mailer"
 	"github.com/memsbdm/restaurant-api/internal/middleware"
 	"github.com/memsbdm/restaurant-api/internal/server"
@@ -15,6 +18,8 @@ type App struct {
 	DB     *database.DB
 	Cache  cache.Cache
 	Server *server.Server
+
+	stopJobs context.CancelFunc
 }
 
 func New() *App {
@@ -29,14 +34,20 @@ func New() *App {
 
 	server := server.New(cfg, handlers, middle)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	go job.Every(jobsCtx, "place sync", cfg.Jobs.PlaceSyncInterval, services.PlaceSyncService.SyncDue)
+	go job.Every(jobsCtx, "account deletion", cfg.Jobs.AccountDeletionInterval, services.AccountDeletionService.DeleteDue)
+
 	return &App{
-		Cache:  cache,
-		DB:     db,
-		Server: server,
+		Cache:    cache,
+		DB:       db,
+		Server:   server,
+		stopJobs: stopJobs,
 	}
 }
 
 func (a *App) Cleanup() {
+	a.stopJobs()
 	a.DB.Close()
 	a.Cache.Close()
 }
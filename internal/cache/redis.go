@@ -16,8 +16,14 @@ var ErrCacheNotFound = errors.New("cache not found")
 
 type Cache interface {
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
 	Get(ctx context.Context, key string) ([]byte, error)
 	Delete(ctx context.Context, key string) error
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	AddToSet(ctx context.Context, key, member string, ttl time.Duration) error
+	GetSet(ctx context.Context, key string) ([]string, error)
+	RemoveFromSet(ctx context.Context, key string, members ...string) error
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	Close() error
 }
 
@@ -54,6 +60,15 @@ func (c *cache) Set(ctx context.Context, key string, value []byte, ttl time.Dura
 	return nil
 }
 
+// SetNX sets the key only if it does not exist yet and reports whether it did, it is atomic across clients.
+func (c *cache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	ok, err := c.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("error during cache set nx: %w", err)
+	}
+	return ok, nil
+}
+
 func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
 	res, err := c.client.Get(ctx, key).Result()
 	if err != nil {
@@ -73,6 +88,65 @@ func (c *cache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// Expire resets the ttl of an existing key, a missing key is not recreated and returns ErrCacheNotFound.
+func (c *cache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	ok, err := c.client.Expire(ctx, key, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("error during cache expire: %w", err)
+	}
+	if !ok {
+		return ErrCacheNotFound
+	}
+	return nil
+}
+
+// AddToSet adds the member to the set and resets the ttl of the whole set.
+func (c *cache) AddToSet(ctx context.Context, key, member string, ttl time.Duration) error {
+	pipe := c.client.TxPipeline()
+	pipe.SAdd(ctx, key, member)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error during cache set add: %w", err)
+	}
+	return nil
+}
+
+func (c *cache) GetSet(ctx context.Context, key string) ([]string, error) {
+	members, err := c.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error during cache set get: %w", err)
+	}
+	return members, nil
+}
+
+func (c *cache) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+
+	err := c.client.SRem(ctx, key, values...).Err()
+	if err != nil {
+		return fmt.Errorf("error during cache set remove: %w", err)
+	}
+	return nil
+}
+
+// Increment increases the counter by one, the ttl is set when the counter is created.
+func (c *cache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("error during cache increment: %w", err)
+	}
+	return incr.Val(), nil
+}
+
 func (c *cache) Close() error {
 	err := c.client.Close()
 	if err != nil {
@@ -1,8 +1,130 @@
 package enum
 
-type RoleID int16
+type RoleID int32
 
 const (
 	RoleOwner RoleID = iota + 1
 	RoleManager
+	// RoleStaff runs the floor, it can view the menu and toggle article availability
+	RoleStaff
+	// RoleViewer has a read-only access to the restaurant
+	RoleViewer
+	// RoleAccountant reads the restaurant, its menu prices and its reports
+	RoleAccountant
+)
+
+// Permission is an action on a restaurant granted through the member role, see the role_permissions table
+type Permission string
+
+const (
+	PermissionRestaurantRead     Permission = "restaurant:read"
+	PermissionRestaurantWrite    Permission = "restaurant:write"
+	PermissionRestaurantVerify   Permission = "restaurant:verify"
+	PermissionRestaurantTransfer Permission = "restaurant:transfer"
+	PermissionMenuRead           Permission = "menu:read"
+	PermissionMenuWrite          Permission = "menu:write"
+	PermissionMenuAvailability   Permission = "menu:availability"
+	PermissionTeamRead           Permission = "team:read"
+	PermissionTeamManage         Permission = "team:manage"
+	PermissionRolesManage        Permission = "roles:manage"
+	PermissionReportsRead        Permission = "reports:read"
+	PermissionAuditRead          Permission = "audit:read"
+	PermissionAPIKeysManage      Permission = "api_keys:manage"
+)
+
+type VerificationMethod string
+
+const (
+	VerificationMethodEmail    VerificationMethod = "EMAIL"
+	VerificationMethodDocument VerificationMethod = "DOCUMENT"
+)
+
+type VerificationStatus string
+
+const (
+	// VerificationStatusPending waits for the owner to prove control of the restaurant
+	VerificationStatusPending VerificationStatus = "PENDING"
+	// VerificationStatusSubmitted waits for a platform admin review
+	VerificationStatusSubmitted VerificationStatus = "SUBMITTED"
+	VerificationStatusApproved  VerificationStatus = "APPROVED"
+	VerificationStatusRejected  VerificationStatus = "REJECTED"
+)
+
+type OwnershipTransferStatus string
+
+const (
+	OwnershipTransferStatusPending   OwnershipTransferStatus = "PENDING"
+	OwnershipTransferStatusCompleted OwnershipTransferStatus = "COMPLETED"
+	OwnershipTransferStatusCanceled  OwnershipTransferStatus = "CANCELED"
+)
+
+type PlaceUpdateStatus string
+
+const (
+	// PlaceUpdateStatusApplied is a provider change written as is, the owner never edited the field
+	PlaceUpdateStatusApplied PlaceUpdateStatus = "APPLIED"
+	// PlaceUpdateStatusSuggested waits for the owner to accept or dismiss the provider change
+	PlaceUpdateStatusSuggested PlaceUpdateStatus = "SUGGESTED"
+	PlaceUpdateStatusAccepted  PlaceUpdateStatus = "ACCEPTED"
+	PlaceUpdateStatusDismissed PlaceUpdateStatus = "DISMISSED"
+	// PlaceUpdateStatusSuperseded is a suggestion replaced by a newer provider change
+	PlaceUpdateStatusSuperseded PlaceUpdateStatus = "SUPERSEDED"
+)
+
+// RestaurantField names a restaurant field kept in sync with the places provider
+type RestaurantField string
+
+const (
+	RestaurantFieldName    RestaurantField = "name"
+	RestaurantFieldAddress RestaurantField = "address"
+	RestaurantFieldPhone   RestaurantField = "phone"
+	// RestaurantFieldLocation holds both coordinates formatted as "lat,lng"
+	RestaurantFieldLocation RestaurantField = "location"
+)
+
+// AuditAction is what an actor did to an entity of the restaurant, see the audit_logs table
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRequest AuditAction = "request"
+	AuditActionConfirm AuditAction = "confirm"
+	AuditActionApprove AuditAction = "approve"
+	AuditActionReject  AuditAction = "reject"
+	AuditActionCancel  AuditAction = "cancel"
+	AuditActionResend  AuditAction = "resend"
+	AuditActionAccept  AuditAction = "accept"
+	AuditActionDismiss AuditAction = "dismiss"
+	// AuditActionLeave is a member removing themselves from the restaurant
+	AuditActionLeave AuditAction = "leave"
+)
+
+type AuditEntity string
+
+const (
+	AuditEntityRestaurant           AuditEntity = "restaurant"
+	AuditEntitySettings             AuditEntity = "settings"
+	AuditEntityOpeningHours         AuditEntity = "opening_hours"
+	AuditEntityOpeningHourException AuditEntity = "opening_hour_exception"
+	AuditEntityVerification         AuditEntity = "verification"
+	AuditEntityOwnershipTransfer    AuditEntity = "ownership_transfer"
+	AuditEntityPlaceUpdate          AuditEntity = "place_update"
+	AuditEntityInvite               AuditEntity = "invite"
+	AuditEntityMember               AuditEntity = "member"
+	AuditEntityRole                 AuditEntity = "role"
+	AuditEntityMenu                 AuditEntity = "menu"
+	AuditEntityCategory             AuditEntity = "category"
+	AuditEntityArticle              AuditEntity = "article"
+	AuditEntityAPIKey               AuditEntity = "api_key"
+	AuditEntityOrganization         AuditEntity = "organization"
+	AuditEntityOrganizationMember   AuditEntity = "organization_member"
+)
+
+// IdentityProvider is an external provider users can sign in with
+type IdentityProvider string
+
+const (
+	IdentityProviderGoogle IdentityProvider = "google"
 )
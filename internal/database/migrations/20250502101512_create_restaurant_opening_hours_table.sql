@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE restaurants ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Times are stored as minutes since midnight in the restaurant timezone.
+-- A range where closes_at <= opens_at runs overnight into the next day.
+CREATE TABLE restaurant_opening_hours (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+  opens_at SMALLINT NOT NULL CHECK (opens_at BETWEEN 0 AND 1439),
+  closes_at SMALLINT NOT NULL CHECK (closes_at BETWEEN 0 AND 1440)
+);
+
+CREATE INDEX idx_restaurant_opening_hours_restaurant_id ON restaurant_opening_hours (restaurant_id);
+
+-- Exceptions replace the weekly hours for their date.
+-- A row without opens_at/closes_at means the restaurant is closed all day.
+CREATE TABLE restaurant_opening_hour_exceptions (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  opens_at SMALLINT NULL CHECK (opens_at BETWEEN 0 AND 1439),
+  closes_at SMALLINT NULL CHECK (closes_at BETWEEN 0 AND 1440),
+  reason VARCHAR(255) NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  CHECK ((opens_at IS NULL) = (closes_at IS NULL))
+);
+
+CREATE INDEX idx_restaurant_opening_hour_exceptions_restaurant_id_date ON restaurant_opening_hour_exceptions (restaurant_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_restaurant_opening_hour_exceptions_restaurant_id_date;
+DROP TABLE IF EXISTS restaurant_opening_hour_exceptions;
+DROP INDEX IF EXISTS idx_restaurant_opening_hours_restaurant_id;
+DROP TABLE IF EXISTS restaurant_opening_hours;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE restaurant_verifications (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  requested_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reviewed_by_user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+  method VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+  business_email VARCHAR(255) NULL,
+  document_url VARCHAR(255) NULL,
+  rejection_reason TEXT NULL,
+  proof_verified_at TIMESTAMP NULL,
+  reviewed_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON restaurant_verifications
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX idx_restaurant_verifications_restaurant_id ON restaurant_verifications (restaurant_id);
+CREATE INDEX idx_restaurant_verifications_status ON restaurant_verifications (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON restaurant_verifications;
+DROP INDEX IF EXISTS idx_restaurant_verifications_status;
+DROP INDEX IF EXISTS idx_restaurant_verifications_restaurant_id;
+DROP TABLE IF EXISTS restaurant_verifications;
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE restaurant_ownership_transfers (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+  -- Role given to the previous owner, taken from the nominee before the swap
+  from_user_new_role_id SMALLINT NULL REFERENCES roles(id),
+  completed_at TIMESTAMP NULL,
+  canceled_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON restaurant_ownership_transfers
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX idx_restaurant_ownership_transfers_restaurant_id ON restaurant_ownership_transfers (restaurant_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON restaurant_ownership_transfers;
+DROP INDEX IF EXISTS idx_restaurant_ownership_transfers_restaurant_id;
+DROP TABLE IF EXISTS restaurant_ownership_transfers;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE restaurants
+  -- Fields changed by the owner are never overwritten by the places provider
+  ADD COLUMN owner_edited_fields TEXT[] NOT NULL DEFAULT '{}',
+  ADD COLUMN place_synced_at TIMESTAMP NULL;
+
+CREATE TABLE restaurant_place_updates (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  field VARCHAR(20) NOT NULL,
+  old_value TEXT NULL,
+  new_value TEXT NULL,
+  status VARCHAR(20) NOT NULL,
+  resolved_by_user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+  resolved_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON restaurant_place_updates
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX idx_restaurant_place_updates_restaurant_id ON restaurant_place_updates (restaurant_id);
+CREATE INDEX idx_restaurants_place_synced_at ON restaurants (place_synced_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_restaurants_place_synced_at;
+DROP TRIGGER IF EXISTS set_updated_at ON restaurant_place_updates;
+DROP INDEX IF EXISTS idx_restaurant_place_updates_restaurant_id;
+DROP TABLE IF EXISTS restaurant_place_updates;
+ALTER TABLE restaurants
+  DROP COLUMN IF EXISTS place_synced_at,
+  DROP COLUMN IF EXISTS owner_edited_fields;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The timezone stays on restaurants, it is shared with the opening hours
+CREATE TABLE restaurant_settings (
+  restaurant_id UUID PRIMARY KEY REFERENCES restaurants(id) ON DELETE CASCADE,
+  currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+  locale VARCHAR(10) NOT NULL DEFAULT 'en',
+  prices_include_tax BOOLEAN NOT NULL DEFAULT TRUE,
+  -- Percentage, e.g. 20 for 20%
+  default_tax_rate FLOAT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON restaurant_settings
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO restaurant_settings (restaurant_id)
+SELECT id FROM restaurants;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON restaurant_settings;
+DROP TABLE IF EXISTS restaurant_settings;
+-- +goose StatementEnd
@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  name VARCHAR(50) NOT NULL
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON organizations
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Organization members get their role on every restaurant of the organization
+CREATE TABLE organization_users (
+  id SERIAL PRIMARY KEY,
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role_id SMALLINT NOT NULL REFERENCES roles(id),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_users_user_id ON organization_users (user_id);
+
+ALTER TABLE restaurants
+  ADD COLUMN organization_id UUID NULL REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_restaurants_organization_id ON restaurants (organization_id);
+
+-- Shared menus belong to an organization and are inherited by its restaurants
+ALTER TABLE menus
+  ALTER COLUMN restaurant_id DROP NOT NULL,
+  ADD COLUMN organization_id UUID NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  ADD CONSTRAINT menus_owner_check CHECK ((restaurant_id IS NULL) <> (organization_id IS NULL));
+
+CREATE INDEX idx_menus_organization_id ON menus (organization_id);
+
+ALTER TABLE categories ALTER COLUMN restaurant_id DROP NOT NULL;
+ALTER TABLE articles ALTER COLUMN restaurant_id DROP NOT NULL;
+
+CREATE TABLE article_price_overrides (
+  article_id INT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  price NUMERIC(10,2) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (article_id, restaurant_id)
+);
+
+CREATE TRIGGER set_updated_at
+  BEFORE UPDATE ON article_price_overrides
+  FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON article_price_overrides;
+DROP TABLE IF EXISTS article_price_overrides;
+
+DELETE FROM menus WHERE organization_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_menus_organization_id;
+ALTER TABLE articles ALTER COLUMN restaurant_id SET NOT NULL;
+ALTER TABLE categories ALTER COLUMN restaurant_id SET NOT NULL;
+ALTER TABLE menus
+  DROP CONSTRAINT IF EXISTS menus_owner_check,
+  DROP COLUMN IF EXISTS organization_id,
+  ALTER COLUMN restaurant_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_restaurants_organization_id;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_organization_users_user_id;
+DROP TABLE IF EXISTS organization_users;
+DROP TRIGGER IF EXISTS set_updated_at ON organizations;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO roles (id, name)
+VALUES (3, 'STAFF'), (4, 'VIEWER'), (5, 'ACCOUNTANT');
+
+SELECT setval('roles_id_seq', (SELECT MAX(id) FROM roles));
+
+-- An article listed here is out of stock at the restaurant, it works for both owned and inherited menus
+CREATE TABLE article_unavailabilities (
+  article_id INT NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (article_id, restaurant_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS article_unavailabilities;
+
+DELETE FROM restaurant_users WHERE role_id IN (3, 4, 5);
+DELETE FROM organization_users WHERE role_id IN (3, 4, 5);
+DELETE FROM roles WHERE id IN (3, 4, 5);
+SELECT setval('roles_id_seq', (SELECT MAX(id) FROM roles));
+-- +goose StatementEnd
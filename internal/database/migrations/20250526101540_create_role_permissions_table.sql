@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A role without restaurant is a platform role available to every restaurant
+ALTER TABLE roles ADD COLUMN restaurant_id UUID NULL REFERENCES restaurants(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_roles_restaurant_id_name ON roles (restaurant_id, name);
+
+CREATE TABLE role_permissions (
+  role_id SMALLINT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  permission VARCHAR(50) NOT NULL,
+  PRIMARY KEY (role_id, permission)
+);
+
+INSERT INTO role_permissions (role_id, permission)
+VALUES
+  (1, 'restaurant:read'), (1, 'restaurant:write'), (1, 'restaurant:verify'), (1, 'restaurant:transfer'),
+  (1, 'menu:read'), (1, 'menu:write'), (1, 'menu:availability'),
+  (1, 'team:read'), (1, 'team:manage'), (1, 'roles:manage'), (1, 'reports:read'),
+  (2, 'restaurant:read'), (2, 'restaurant:write'),
+  (2, 'menu:read'), (2, 'menu:write'), (2, 'menu:availability'),
+  (2, 'team:read'), (2, 'team:manage'), (2, 'reports:read'),
+  (3, 'restaurant:read'), (3, 'menu:read'), (3, 'menu:availability'), (3, 'team:read'),
+  (4, 'restaurant:read'), (4, 'menu:read'), (4, 'team:read'),
+  (5, 'reports:read');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS role_permissions;
+
+DELETE FROM restaurant_users WHERE role_id IN (SELECT id FROM roles WHERE restaurant_id IS NOT NULL);
+DELETE FROM roles WHERE restaurant_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_roles_restaurant_id_name;
+ALTER TABLE roles DROP COLUMN IF EXISTS restaurant_id;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Rows are only ever inserted, the actor is cleared once the user is deleted
+CREATE TABLE audit_logs (
+  id BIGSERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  actor_user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+  action VARCHAR(50) NOT NULL,
+  entity_type VARCHAR(50) NOT NULL,
+  entity_id VARCHAR(100) NOT NULL,
+  before_data JSONB NULL,
+  after_data JSONB NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_restaurant_id_id ON audit_logs (restaurant_id, id DESC);
+
+INSERT INTO role_permissions (role_id, permission)
+VALUES
+  (1, 'audit:read'), (2, 'audit:read');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'audit:read';
+DROP INDEX IF EXISTS idx_audit_logs_restaurant_id_id;
+DROP TABLE IF EXISTS audit_logs;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Accounts are hard-deleted once delete_after has passed, signing in again before that cancels the deletion
+CREATE TABLE user_deletions (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  delete_after TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_user_deletions_delete_after ON user_deletions (delete_after);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_deletions_delete_after;
+DROP TABLE IF EXISTS user_deletions;
+-- +goose StatementEnd
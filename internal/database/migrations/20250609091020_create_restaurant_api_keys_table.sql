@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Only the SHA-256 hash of the secret is stored, the prefix identifies the key without revealing it
+CREATE TABLE restaurant_api_keys (
+  id SERIAL PRIMARY KEY,
+  restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  prefix VARCHAR(16) NOT NULL UNIQUE,
+  secret_hash VARCHAR(64) NOT NULL,
+  scopes TEXT[] NOT NULL,
+  created_by_user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+  expires_at TIMESTAMP NULL,
+  last_used_at TIMESTAMP NULL,
+  revoked_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_restaurant_api_keys_restaurant_id ON restaurant_api_keys (restaurant_id);
+
+INSERT INTO role_permissions (role_id, permission)
+VALUES
+  (1, 'api_keys:manage');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'api_keys:manage';
+DROP INDEX IF EXISTS idx_restaurant_api_keys_restaurant_id;
+DROP TABLE IF EXISTS restaurant_api_keys;
+-- +goose StatementEnd
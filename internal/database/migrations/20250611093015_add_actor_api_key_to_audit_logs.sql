@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Changes made by an integration are attributed to its API key, actor_user_id is then NULL
+ALTER TABLE audit_logs
+  ADD COLUMN actor_api_key_id INT NULL REFERENCES restaurant_api_keys(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS actor_api_key_id;
+-- +goose StatementEnd
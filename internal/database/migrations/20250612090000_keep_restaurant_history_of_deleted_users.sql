@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted accounts leave the restaurant history in place, the user references are then NULL
+ALTER TABLE restaurant_ownership_transfers
+  ALTER COLUMN from_user_id DROP NOT NULL,
+  ALTER COLUMN to_user_id DROP NOT NULL,
+  DROP CONSTRAINT restaurant_ownership_transfers_from_user_id_fkey,
+  DROP CONSTRAINT restaurant_ownership_transfers_to_user_id_fkey,
+  ADD CONSTRAINT restaurant_ownership_transfers_from_user_id_fkey
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE SET NULL,
+  ADD CONSTRAINT restaurant_ownership_transfers_to_user_id_fkey
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE restaurant_verifications
+  ALTER COLUMN requested_by_user_id DROP NOT NULL,
+  DROP CONSTRAINT restaurant_verifications_requested_by_user_id_fkey,
+  ADD CONSTRAINT restaurant_verifications_requested_by_user_id_fkey
+    FOREIGN KEY (requested_by_user_id) REFERENCES users(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM restaurant_ownership_transfers WHERE from_user_id IS NULL OR to_user_id IS NULL;
+DELETE FROM restaurant_verifications WHERE requested_by_user_id IS NULL;
+
+ALTER TABLE restaurant_ownership_transfers
+  DROP CONSTRAINT restaurant_ownership_transfers_from_user_id_fkey,
+  DROP CONSTRAINT restaurant_ownership_transfers_to_user_id_fkey,
+  ADD CONSTRAINT restaurant_ownership_transfers_from_user_id_fkey
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  ADD CONSTRAINT restaurant_ownership_transfers_to_user_id_fkey
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  ALTER COLUMN from_user_id SET NOT NULL,
+  ALTER COLUMN to_user_id SET NOT NULL;
+
+ALTER TABLE restaurant_verifications
+  DROP CONSTRAINT restaurant_verifications_requested_by_user_id_fkey,
+  ADD CONSTRAINT restaurant_verifications_requested_by_user_id_fkey
+    FOREIGN KEY (requested_by_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  ALTER COLUMN requested_by_user_id SET NOT NULL;
+-- +goose StatementEnd
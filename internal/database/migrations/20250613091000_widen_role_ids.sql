@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Role IDs come from a single sequence shared by every restaurant, SMALLINT would run out of values
+ALTER TABLE restaurant_users ALTER COLUMN role_id TYPE INTEGER;
+ALTER TABLE organization_users ALTER COLUMN role_id TYPE INTEGER;
+ALTER TABLE restaurant_invites ALTER COLUMN role_id TYPE INTEGER;
+ALTER TABLE role_permissions ALTER COLUMN role_id TYPE INTEGER;
+ALTER TABLE restaurant_ownership_transfers ALTER COLUMN from_user_new_role_id TYPE INTEGER;
+ALTER TABLE roles ALTER COLUMN id TYPE INTEGER;
+ALTER SEQUENCE roles_id_seq AS INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER SEQUENCE roles_id_seq AS SMALLINT;
+ALTER TABLE roles ALTER COLUMN id TYPE SMALLINT;
+ALTER TABLE restaurant_ownership_transfers ALTER COLUMN from_user_new_role_id TYPE SMALLINT;
+ALTER TABLE role_permissions ALTER COLUMN role_id TYPE SMALLINT;
+ALTER TABLE restaurant_invites ALTER COLUMN role_id TYPE SMALLINT;
+ALTER TABLE organization_users ALTER COLUMN role_id TYPE SMALLINT;
+ALTER TABLE restaurant_users ALTER COLUMN role_id TYPE SMALLINT;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- No route serves reports yet, accountants need the restaurant and its menu prices to do their job
+INSERT INTO role_permissions (role_id, permission)
+VALUES (5, 'restaurant:read'), (5, 'menu:read')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions
+WHERE role_id = 5 AND permission IN ('restaurant:read', 'menu:read');
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- name: CreateArticle :one
+INSERT INTO articles (name, description, price, article_order, category_id, restaurant_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetArticleMenuOwner :one
+SELECT m.restaurant_id, m.organization_id
+FROM articles a
+INNER JOIN categories c ON c.id = a.category_id
+INNER JOIN menus m ON m.id = c.menu_id
+WHERE a.id = $1;
+
+-- name: GetArticlesByMenuIDsForRestaurant :many
+SELECT a.*, o.price AS override_price, NOT EXISTS(
+  SELECT 1 FROM article_unavailabilities au
+  WHERE au.article_id = a.id AND au.restaurant_id = sqlc.arg(restaurant_id)
+) AS is_available
+FROM articles a
+INNER JOIN categories c ON c.id = a.category_id
+LEFT JOIN article_price_overrides o ON o.article_id = a.id AND o.restaurant_id = sqlc.arg(restaurant_id)
+WHERE c.menu_id = ANY(sqlc.arg(menu_ids)::int[])
+ORDER BY a.article_order, a.id;
+
+-- name: GetArticlePriceOverride :one
+SELECT * FROM article_price_overrides
+WHERE article_id = $1 AND restaurant_id = $2;
+
+-- name: UpsertArticlePriceOverride :one
+INSERT INTO article_price_overrides (article_id, restaurant_id, price)
+VALUES ($1, $2, $3)
+ON CONFLICT (article_id, restaurant_id) DO UPDATE SET price = EXCLUDED.price
+RETURNING *;
+
+-- name: DeleteArticlePriceOverride :one
+DELETE FROM article_price_overrides
+WHERE article_id = $1 AND restaurant_id = $2
+RETURNING *;
+
+-- name: SetArticleUnavailable :exec
+INSERT INTO article_unavailabilities (article_id, restaurant_id)
+VALUES ($1, $2)
+ON CONFLICT (article_id, restaurant_id) DO NOTHING;
+
+-- name: DeleteArticleUnavailability :exec
+DELETE FROM article_unavailabilities
+WHERE article_id = $1 AND restaurant_id = $2;
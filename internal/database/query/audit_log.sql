@@ -0,0 +1,15 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs
+(restaurant_id, actor_user_id, actor_api_key_id, action, entity_type, entity_id, before_data, after_data)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAuditLogsByRestaurantID :many
+SELECT * FROM audit_logs
+WHERE restaurant_id = sqlc.arg(restaurant_id)
+AND (sqlc.narg(actor_user_id)::uuid IS NULL OR actor_user_id = sqlc.narg(actor_user_id))
+AND (sqlc.narg(entity_type)::varchar IS NULL OR entity_type = sqlc.narg(entity_type))
+AND (sqlc.narg(created_from)::timestamp IS NULL OR created_at >= sqlc.narg(created_from))
+AND (sqlc.narg(created_to)::timestamp IS NULL OR created_at < sqlc.narg(created_to))
+AND (sqlc.narg(cursor)::bigint IS NULL OR id < sqlc.narg(cursor))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size);
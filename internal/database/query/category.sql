@@ -0,0 +1,12 @@
+-- name: CreateCategory :one
+INSERT INTO categories (name, description, category_order, menu_id, restaurant_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetCategoryByID :one
+SELECT * FROM categories WHERE id = $1;
+
+-- name: GetCategoriesByMenuIDs :many
+SELECT * FROM categories
+WHERE menu_id = ANY(sqlc.arg(menu_ids)::int[])
+ORDER BY category_order, id;
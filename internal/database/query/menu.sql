@@ -11,3 +11,24 @@ LIMIT 1
 INSERT INTO  menus (name, is_active, restaurant_id)
 VALUES ($1, $2, $3)
 RETURNING *;
+
+-- name: CreateOrganizationMenu :one
+INSERT INTO menus (name, is_active, organization_id)
+VALUES ($1, TRUE, $2)
+RETURNING *;
+
+-- name: GetOrganizationMenuByID :one
+SELECT * FROM menus
+WHERE id = $1 AND organization_id = $2;
+
+-- name: GetMenusByOrganizationID :many
+SELECT * FROM menus
+WHERE organization_id = $1
+ORDER BY id;
+
+-- name: GetMenusByRestaurantID :many
+SELECT m.*
+FROM menus m
+WHERE m.restaurant_id = sqlc.arg(restaurant_id)::uuid
+OR m.organization_id = (SELECT r.organization_id FROM restaurants r WHERE r.id = sqlc.arg(restaurant_id)::uuid)
+ORDER BY m.organization_id NULLS FIRST, m.id;
@@ -0,0 +1,28 @@
+-- name: GetOpeningHoursByRestaurantID :many
+SELECT * FROM restaurant_opening_hours
+WHERE restaurant_id = $1
+ORDER BY day_of_week, opens_at;
+
+-- name: CreateOpeningHour :one
+INSERT INTO restaurant_opening_hours (restaurant_id, day_of_week, opens_at, closes_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: DeleteOpeningHoursByRestaurantID :exec
+DELETE FROM restaurant_opening_hours
+WHERE restaurant_id = $1;
+
+-- name: GetOpeningHourExceptionsByRestaurantID :many
+SELECT * FROM restaurant_opening_hour_exceptions
+WHERE restaurant_id = $1 AND date >= $2
+ORDER BY date, opens_at NULLS FIRST;
+
+-- name: CreateOpeningHourException :one
+INSERT INTO restaurant_opening_hour_exceptions (restaurant_id, date, opens_at, closes_at, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: DeleteOpeningHourException :one
+DELETE FROM restaurant_opening_hour_exceptions
+WHERE id = $1 AND restaurant_id = $2
+RETURNING *;
@@ -0,0 +1,43 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations WHERE id = $1;
+
+-- name: GetOrganizationsByUserID :many
+SELECT o.*
+FROM organizations o
+INNER JOIN organization_users ou ON ou.organization_id = o.id
+WHERE ou.user_id = $1
+ORDER BY o.name;
+
+-- name: AddOrganizationUser :exec
+INSERT INTO organization_users (organization_id, user_id, role_id)
+VALUES ($1, $2, $3);
+
+-- name: GetOrganizationUserRoleID :one
+SELECT role_id FROM organization_users
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: GetOrganizationMembers :many
+SELECT u.id AS user_id, u.name, u.email, ou.role_id, r.name AS role_name, ou.created_at
+FROM organization_users ou
+INNER JOIN users u ON u.id = ou.user_id
+INNER JOIN roles r ON r.id = ou.role_id
+WHERE ou.organization_id = $1
+ORDER BY ou.created_at;
+
+-- name: CountOrganizationUsersByRoleID :one
+SELECT COUNT(*) FROM organization_users
+WHERE organization_id = $1 AND role_id = $2;
+
+-- name: DeleteOrganizationUser :execrows
+DELETE FROM organization_users
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: LockOrganization :exec
+SELECT id FROM organizations
+WHERE id = $1
+FOR UPDATE;
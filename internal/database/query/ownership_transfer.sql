@@ -0,0 +1,28 @@
+-- name: CreateOwnershipTransfer :one
+INSERT INTO restaurant_ownership_transfers (restaurant_id, from_user_id, to_user_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOwnershipTransferByID :one
+SELECT * FROM restaurant_ownership_transfers WHERE id = $1;
+
+-- name: GetPendingOwnershipTransferByRestaurantID :one
+SELECT * FROM restaurant_ownership_transfers
+WHERE restaurant_id = $1 AND status = 'PENDING'
+LIMIT 1;
+
+-- name: CompleteOwnershipTransfer :one
+UPDATE restaurant_ownership_transfers
+SET status = 'COMPLETED', from_user_new_role_id = $1, completed_at = NOW()
+WHERE id = $2
+RETURNING *;
+
+-- name: CancelOwnershipTransfer :exec
+UPDATE restaurant_ownership_transfers
+SET status = 'CANCELED', canceled_at = NOW()
+WHERE id = $1;
+
+-- name: CancelPendingOwnershipTransfersByUserID :exec
+UPDATE restaurant_ownership_transfers
+SET status = 'CANCELED', canceled_at = NOW()
+WHERE status = 'PENDING' AND (from_user_id = $1 OR to_user_id = $1);
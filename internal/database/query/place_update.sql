@@ -0,0 +1,37 @@
+-- name: CreateRestaurantPlaceUpdate :one
+INSERT INTO restaurant_place_updates
+(restaurant_id, field, old_value, new_value, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetRestaurantPlaceUpdateByID :one
+SELECT * FROM restaurant_place_updates
+WHERE id = $1 AND restaurant_id = $2;
+
+-- name: GetRestaurantPlaceUpdatesByRestaurantID :many
+SELECT * FROM restaurant_place_updates
+WHERE restaurant_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: IsRestaurantPlaceUpdateKnown :one
+SELECT EXISTS (
+    SELECT 1
+    FROM restaurant_place_updates
+    WHERE restaurant_id = $1
+    AND field = $2
+    AND new_value IS NOT DISTINCT FROM $3
+    AND status IN ('SUGGESTED', 'DISMISSED')
+);
+
+-- name: SupersedeSuggestedRestaurantPlaceUpdates :exec
+UPDATE restaurant_place_updates
+SET status = 'SUPERSEDED'
+WHERE restaurant_id = $1
+AND field = $2
+AND status = 'SUGGESTED';
+
+-- name: ResolveRestaurantPlaceUpdate :one
+UPDATE restaurant_place_updates
+SET status = $1, resolved_by_user_id = $2, resolved_at = CURRENT_TIMESTAMP
+WHERE id = $3
+RETURNING *;
@@ -1,11 +1,16 @@
 -- name: GetRestaurantByID :one
 SELECT * FROM restaurants WHERE id = $1;
 
+-- name: GetRestaurantByIDForUpdate :one
+SELECT * FROM restaurants
+WHERE id = $1
+FOR UPDATE;
+
 -- name: GetRestaurantsByUserID :many
 SELECT r.*
 FROM restaurants r
-LEFT JOIN restaurant_users ru ON ru.restaurant_id = r.id
-WHERE ru.user_id = $1;
+WHERE r.id IN (SELECT restaurant_id FROM restaurant_users WHERE user_id = $1)
+OR r.organization_id IN (SELECT organization_id FROM organization_users WHERE user_id = $1);
 
 -- name: IsRestaurantAlreadyTaken :one
 SELECT EXISTS (
@@ -20,6 +25,88 @@ SELECT EXISTS (
 
 -- name: CreateRestaurant :one
 INSERT INTO restaurants
-(name, alias, address, lat, lng, phone, place_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+(name, alias, address, lat, lng, phone, place_id, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateRestaurantTimezone :exec
+UPDATE restaurants
+SET timezone = $1
+WHERE id = $2;
+
+-- name: SetRestaurantVerified :exec
+UPDATE restaurants
+SET is_verified = TRUE
+WHERE id = $1;
+
+-- name: IsPlaceVerifiedByAnotherRestaurant :one
+SELECT EXISTS (
+    SELECT 1
+    FROM restaurants
+    WHERE place_id = $1
+    AND id <> $2
+    AND is_verified = TRUE
+);
+
+-- name: GetCompetingRestaurantClaimUsers :many
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, u.id AS user_id, u.name AS user_name, u.email
+FROM restaurants r
+INNER JOIN restaurant_users ru ON ru.restaurant_id = r.id
+INNER JOIN users u ON u.id = ru.user_id
+WHERE r.place_id = $1
+AND r.id <> $2
+AND r.is_verified = FALSE;
+
+-- name: GetNearbyRestaurants :many
+SELECT r.*, earth_distance(ll_to_earth(sqlc.arg(lat)::float, sqlc.arg(lng)::float), ll_to_earth(r.lat, r.lng))::float AS distance
+FROM restaurants r
+WHERE r.is_verified = TRUE
+AND r.lat IS NOT NULL
+AND r.lng IS NOT NULL
+AND earth_box(ll_to_earth(sqlc.arg(lat)::float, sqlc.arg(lng)::float), sqlc.arg(radius)::float) @> ll_to_earth(r.lat, r.lng)
+AND earth_distance(ll_to_earth(sqlc.arg(lat)::float, sqlc.arg(lng)::float), ll_to_earth(r.lat, r.lng)) <= sqlc.arg(radius)::float
+ORDER BY distance
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: UpdateRestaurantDetails :one
+UPDATE restaurants
+SET name = $1, address = $2, phone = $3, owner_edited_fields = $4
+WHERE id = $5
 RETURNING *;
+
+-- name: GetRestaurantsDueForPlaceSync :many
+SELECT * FROM restaurants
+WHERE place_synced_at IS NULL OR place_synced_at < sqlc.arg(synced_before)::timestamp
+ORDER BY place_synced_at NULLS FIRST
+LIMIT sqlc.arg(batch_size);
+
+-- name: UpdateRestaurantPlaceDetails :exec
+UPDATE restaurants
+SET name = $1, address = $2, lat = $3, lng = $4, phone = $5
+WHERE id = $6;
+
+-- name: SetRestaurantPlaceSynced :exec
+UPDATE restaurants
+SET place_synced_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetRestaurantsByOrganizationID :many
+SELECT * FROM restaurants
+WHERE organization_id = $1
+ORDER BY name;
+
+-- name: SetRestaurantOrganization :exec
+UPDATE restaurants
+SET organization_id = $1
+WHERE id = $2;
+
+-- name: LockRestaurantsByPlaceID :exec
+SELECT id FROM restaurants
+WHERE place_id = $1
+ORDER BY id
+FOR UPDATE;
+
+-- name: LockRestaurant :exec
+SELECT id FROM restaurants
+WHERE id = $1
+FOR UPDATE;
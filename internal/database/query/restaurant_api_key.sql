@@ -0,0 +1,23 @@
+-- name: CreateRestaurantAPIKey :one
+INSERT INTO restaurant_api_keys (restaurant_id, name, prefix, secret_hash, scopes, created_by_user_id, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetRestaurantAPIKeysByRestaurantID :many
+SELECT * FROM restaurant_api_keys
+WHERE restaurant_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: GetRestaurantAPIKeyByPrefix :one
+SELECT * FROM restaurant_api_keys WHERE prefix = $1;
+
+-- name: RevokeRestaurantAPIKey :one
+UPDATE restaurant_api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: TouchRestaurantAPIKey :exec
+UPDATE restaurant_api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
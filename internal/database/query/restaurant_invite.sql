@@ -0,0 +1,33 @@
+-- name: CreateRestaurantInvite :one
+INSERT INTO restaurant_invites (restaurant_id, invited_by_user_id, email, role_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRestaurantInviteByID :one
+SELECT * FROM restaurant_invites
+WHERE id = $1;
+
+-- name: GetPendingRestaurantInvitesByRestaurantID :many
+SELECT ri.*, r.name AS role_name
+FROM restaurant_invites ri
+INNER JOIN roles r ON r.id = ri.role_id
+WHERE ri.restaurant_id = $1 AND ri.accepted_at IS NULL AND ri.canceled_at IS NULL
+ORDER BY ri.created_at DESC;
+
+-- name: PendingRestaurantInviteExists :one
+SELECT EXISTS(
+  SELECT 1 FROM restaurant_invites
+  WHERE restaurant_id = $1 AND email = $2 AND accepted_at IS NULL AND canceled_at IS NULL
+);
+
+-- name: AcceptRestaurantInvite :one
+UPDATE restaurant_invites
+SET accepted_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelRestaurantInvite :one
+UPDATE restaurant_invites
+SET canceled_by_user_id = $1, canceled_at = NOW()
+WHERE id = $2
+RETURNING *;
@@ -0,0 +1,17 @@
+-- name: CreateRestaurantSettings :one
+INSERT INTO restaurant_settings
+(restaurant_id, currency, locale, prices_include_tax, default_tax_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetRestaurantSettings :one
+SELECT s.*, r.timezone
+FROM restaurant_settings s
+INNER JOIN restaurants r ON r.id = s.restaurant_id
+WHERE s.restaurant_id = $1;
+
+-- name: UpdateRestaurantSettings :one
+UPDATE restaurant_settings
+SET currency = $1, locale = $2, prices_include_tax = $3, default_tax_rate = $4
+WHERE restaurant_id = $5
+RETURNING *;
@@ -2,11 +2,83 @@
 SELECT role_id FROM restaurant_users 
 WHERE restaurant_id = $1 AND user_id = $2;
 
--- name: GetAnyRestaurantUserLinkByUserID :one
-SELECT * FROM restaurant_users
-WHERE user_id = $1
+-- name: GetAnyRestaurantAccessByUserID :one
+SELECT ru.restaurant_id, ru.role_id
+FROM restaurant_users ru
+WHERE ru.user_id = $1
+UNION ALL
+SELECT r.id AS restaurant_id, ou.role_id
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+WHERE ou.user_id = $1
 LIMIT 1;
 
 -- name: AddRestaurantUser :exec
 INSERT INTO restaurant_users (user_id, restaurant_id, role_id)
 VALUES ($1, $2, $3);
+
+-- name: DeleteRestaurantUsersByRestaurantID :exec
+DELETE FROM restaurant_users
+WHERE restaurant_id = $1;
+
+-- name: UpdateRestaurantUserRole :exec
+UPDATE restaurant_users
+SET role_id = $1
+WHERE restaurant_id = $2 AND user_id = $3;
+
+-- name: GetEffectiveRestaurantUserRoleID :one
+SELECT role_id FROM (
+    SELECT ru.role_id, 1 AS precedence
+    FROM restaurant_users ru
+    WHERE ru.restaurant_id = $1 AND ru.user_id = $2
+    UNION ALL
+    SELECT ou.role_id, 2 AS precedence
+    FROM organization_users ou
+    INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+    WHERE r.id = $1 AND ou.user_id = $2
+) roles
+ORDER BY precedence
+LIMIT 1;
+
+-- name: GetRestaurantMembers :many
+SELECT u.id AS user_id, u.name, u.email, u.avatar_url, ru.role_id, ro.name AS role_name, FALSE AS is_inherited
+FROM restaurant_users ru
+INNER JOIN users u ON u.id = ru.user_id
+INNER JOIN roles ro ON ro.id = ru.role_id
+WHERE ru.restaurant_id = $1
+UNION ALL
+SELECT u.id AS user_id, u.name, u.email, u.avatar_url, ou.role_id, ro.name AS role_name, TRUE AS is_inherited
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+INNER JOIN users u ON u.id = ou.user_id
+INNER JOIN roles ro ON ro.id = ou.role_id
+WHERE r.id = $1 AND NOT EXISTS (
+    SELECT 1 FROM restaurant_users ru
+    WHERE ru.restaurant_id = r.id AND ru.user_id = ou.user_id
+)
+ORDER BY role_id, name;
+
+-- name: CountRestaurantUsersByRoleID :one
+SELECT COUNT(*) FROM restaurant_users
+WHERE restaurant_id = $1 AND role_id = $2;
+
+-- name: DeleteRestaurantUser :execrows
+DELETE FROM restaurant_users
+WHERE restaurant_id = $1 AND user_id = $2;
+
+-- name: GetUserRestaurantRoles :many
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, r.alias AS restaurant_alias, ru.role_id, ro.name AS role_name, FALSE AS is_inherited
+FROM restaurant_users ru
+INNER JOIN restaurants r ON r.id = ru.restaurant_id
+INNER JOIN roles ro ON ro.id = ru.role_id
+WHERE ru.user_id = $1
+UNION ALL
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, r.alias AS restaurant_alias, ou.role_id, ro.name AS role_name, TRUE AS is_inherited
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+INNER JOIN roles ro ON ro.id = ou.role_id
+WHERE ou.user_id = $1 AND NOT EXISTS (
+    SELECT 1 FROM restaurant_users ru
+    WHERE ru.restaurant_id = r.id AND ru.user_id = ou.user_id
+)
+ORDER BY restaurant_name;
@@ -0,0 +1,46 @@
+-- name: CreateRestaurantVerification :one
+INSERT INTO restaurant_verifications
+(restaurant_id, requested_by_user_id, method, status, business_email, document_url)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetRestaurantVerificationByID :one
+SELECT * FROM restaurant_verifications WHERE id = $1;
+
+-- name: GetLatestRestaurantVerificationByRestaurantID :one
+SELECT * FROM restaurant_verifications
+WHERE restaurant_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: RestaurantVerificationInProgressExists :one
+SELECT EXISTS (
+    SELECT 1
+    FROM restaurant_verifications
+    WHERE restaurant_id = $1
+    AND status IN ('PENDING', 'SUBMITTED')
+);
+
+-- name: GetSubmittedRestaurantVerifications :many
+SELECT rv.*, r.name AS restaurant_name, r.address AS restaurant_address, r.place_id
+FROM restaurant_verifications rv
+INNER JOIN restaurants r ON r.id = rv.restaurant_id
+WHERE rv.status = 'SUBMITTED'
+ORDER BY rv.created_at;
+
+-- name: SubmitRestaurantVerification :exec
+UPDATE restaurant_verifications
+SET status = 'SUBMITTED', proof_verified_at = NOW()
+WHERE id = $1;
+
+-- name: ReviewRestaurantVerification :one
+UPDATE restaurant_verifications
+SET status = $1, reviewed_by_user_id = $2, rejection_reason = $3, reviewed_at = NOW()
+WHERE id = $4
+RETURNING *;
+
+-- name: RejectInProgressRestaurantVerifications :exec
+UPDATE restaurant_verifications
+SET status = 'REJECTED', rejection_reason = $2, reviewed_at = NOW()
+WHERE restaurant_id = $1
+AND status IN ('PENDING', 'SUBMITTED');
@@ -0,0 +1,62 @@
+-- name: GetRolesByRestaurantID :many
+SELECT * FROM roles
+WHERE restaurant_id IS NULL OR restaurant_id = $1
+ORDER BY id;
+
+-- name: GetRoleByID :one
+SELECT * FROM roles WHERE id = $1;
+
+-- name: RoleNameTaken :one
+SELECT EXISTS(
+  SELECT 1 FROM roles
+  WHERE (restaurant_id IS NULL OR restaurant_id = sqlc.arg(restaurant_id)) AND LOWER(name) = LOWER(sqlc.arg(name)) AND id <> sqlc.arg(id)
+);
+
+-- name: CountRolesByRestaurantID :one
+SELECT COUNT(*) FROM roles
+WHERE restaurant_id = sqlc.arg(restaurant_id)::uuid;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, restaurant_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateRoleName :one
+UPDATE roles
+SET name = $1
+WHERE id = $2
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles WHERE id = $1;
+
+-- name: LockRole :exec
+SELECT id FROM roles
+WHERE id = $1
+FOR UPDATE;
+
+-- name: RoleInUse :one
+SELECT EXISTS(
+  SELECT 1 FROM restaurant_users WHERE role_id = $1
+) OR EXISTS(
+  SELECT 1 FROM restaurant_invites
+  WHERE role_id = $1 AND accepted_at IS NULL AND canceled_at IS NULL
+);
+
+-- name: GetRolePermissionsByRoleIDs :many
+SELECT * FROM role_permissions
+WHERE role_id = ANY(sqlc.arg(role_ids)::int[])
+ORDER BY role_id, permission;
+
+-- name: AddRolePermissions :exec
+INSERT INTO role_permissions (role_id, permission)
+SELECT sqlc.arg(role_id), unnest(sqlc.arg(permissions)::varchar[]);
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions WHERE role_id = $1;
+
+-- name: RoleHasPermission :one
+SELECT EXISTS(
+  SELECT 1 FROM role_permissions
+  WHERE role_id = $1 AND permission = $2
+);
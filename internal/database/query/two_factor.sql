@@ -0,0 +1,28 @@
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1;
+
+-- name: UpsertUserTOTP :exec
+INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, confirmed_at = NULL, last_used_step = NULL, created_at = NOW();
+
+-- name: ConfirmUserTOTP :exec
+UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2 WHERE user_id = $1;
+
+-- name: UseUserTOTPStep :execrows
+UPDATE user_totp SET last_used_step = $2
+WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2);
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: CreateUserRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg(user_id), unnest(sqlc.arg(code_hashes)::varchar[]);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1;
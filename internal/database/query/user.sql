@@ -9,8 +9,11 @@ INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING *;
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = $1, is_email_verified = $2, avatar_url= $3
-WHERE id = $4
+SET name = COALESCE(sqlc.narg(name), name),
+    email = COALESCE(sqlc.narg(email), email),
+    is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+    avatar_url = CASE WHEN sqlc.narg(avatar_url)::varchar IS NULL THEN avatar_url ELSE NULLIF(sqlc.narg(avatar_url), '') END
+WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: UserEmailTaken :one
@@ -19,3 +22,9 @@ SELECT EXISTS(
   WHERE email = $1 AND is_email_verified = TRUE
 );
 
+
+-- name: GetVerifiedUserByEmail :one
+SELECT * FROM users WHERE email = $1 AND is_email_verified = TRUE;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password = $1 WHERE id = $2;
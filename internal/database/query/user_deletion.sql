@@ -0,0 +1,55 @@
+-- name: ScheduleUserDeletion :one
+INSERT INTO user_deletions (user_id, delete_after)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET requested_at = NOW(), delete_after = EXCLUDED.delete_after
+RETURNING *;
+
+-- name: CancelUserDeletion :execrows
+DELETE FROM user_deletions WHERE user_id = $1;
+
+-- name: GetUserDeletionsDue :many
+SELECT * FROM user_deletions
+WHERE delete_after <= NOW()
+ORDER BY delete_after
+LIMIT sqlc.arg(batch_size);
+
+-- name: GetSoleOwnedRestaurantsByUserID :many
+SELECT r.id, r.name
+FROM restaurants r
+WHERE (
+    EXISTS (
+        SELECT 1 FROM restaurant_users ru
+        WHERE ru.restaurant_id = r.id AND ru.user_id = sqlc.arg(user_id) AND ru.role_id = sqlc.arg(role_id)
+    )
+    OR EXISTS (
+        SELECT 1 FROM organization_users ou
+        WHERE ou.organization_id = r.organization_id AND ou.user_id = sqlc.arg(user_id) AND ou.role_id = sqlc.arg(role_id)
+    )
+)
+AND NOT EXISTS (
+    SELECT 1 FROM restaurant_users ru
+    WHERE ru.restaurant_id = r.id AND ru.user_id <> sqlc.arg(user_id) AND ru.role_id = sqlc.arg(role_id)
+)
+AND NOT EXISTS (
+    SELECT 1 FROM organization_users ou
+    WHERE ou.organization_id = r.organization_id AND ou.user_id <> sqlc.arg(user_id) AND ou.role_id = sqlc.arg(role_id)
+)
+ORDER BY r.name;
+
+-- name: AnonymizeUserAuditLogs :exec
+-- email and anonymous_email are JSON encoded so only whole string values are replaced
+UPDATE audit_logs
+SET actor_user_id = NULLIF(actor_user_id, sqlc.arg(user_id)::uuid),
+    entity_id = REPLACE(entity_id, sqlc.arg(user_id)::text, sqlc.arg(anonymous_id)::text),
+    before_data = REPLACE(REPLACE(before_data::text, sqlc.arg(user_id)::text, sqlc.arg(anonymous_id)::text), sqlc.arg(email)::text, sqlc.arg(anonymous_email)::text)::jsonb,
+    after_data = REPLACE(REPLACE(after_data::text, sqlc.arg(user_id)::text, sqlc.arg(anonymous_id)::text), sqlc.arg(email)::text, sqlc.arg(anonymous_email)::text)::jsonb
+WHERE actor_user_id = sqlc.arg(user_id)
+OR entity_id = sqlc.arg(user_id)::text
+OR STRPOS(before_data::text, sqlc.arg(user_id)::text) > 0
+OR STRPOS(after_data::text, sqlc.arg(user_id)::text) > 0
+OR STRPOS(before_data::text, sqlc.arg(email)::text) > 0
+OR STRPOS(after_data::text, sqlc.arg(email)::text) > 0;
+
+-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1;
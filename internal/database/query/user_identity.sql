@@ -0,0 +1,7 @@
+-- name: GetUserByIdentity :one
+SELECT u.* FROM users u
+INNER JOIN user_identities ui ON ui.user_id = u.id
+WHERE ui.provider = $1 AND ui.subject = $2;
+
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (user_id, provider, subject) VALUES ($1, $2, $3);
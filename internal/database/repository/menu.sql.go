@@ -14,13 +14,13 @@ import (
 const createMenu = `-- name: CreateMenu :one
 INSERT INTO  menus (name, is_active, restaurant_id)
 VALUES ($1, $2, $3)
-RETURNING id, created_at, updated_at, name, is_active, restaurant_id
+RETURNING id, created_at, updated_at, name, is_active, restaurant_id, organization_id
 `
 
 type CreateMenuParams struct {
 	Name         string
 	IsActive     bool
-	RestaurantID uuid.UUID
+	RestaurantID *uuid.UUID
 }
 
 func (q *Queries) CreateMenu(ctx context.Context, arg CreateMenuParams) (Menu, error) {
@@ -33,6 +33,128 @@ func (q *Queries) CreateMenu(ctx context.Context, arg CreateMenuParams) (Menu, e
 		&i.Name,
 		&i.IsActive,
 		&i.RestaurantID,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const createOrganizationMenu = `-- name: CreateOrganizationMenu :one
+INSERT INTO menus (name, is_active, organization_id)
+VALUES ($1, TRUE, $2)
+RETURNING id, created_at, updated_at, name, is_active, restaurant_id, organization_id
+`
+
+type CreateOrganizationMenuParams struct {
+	Name           string
+	OrganizationID *uuid.UUID
+}
+
+func (q *Queries) CreateOrganizationMenu(ctx context.Context, arg CreateOrganizationMenuParams) (Menu, error) {
+	row := q.db.QueryRow(ctx, createOrganizationMenu, arg.Name, arg.OrganizationID)
+	var i Menu
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.IsActive,
+		&i.RestaurantID,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getMenusByOrganizationID = `-- name: GetMenusByOrganizationID :many
+SELECT id, created_at, updated_at, name, is_active, restaurant_id, organization_id FROM menus
+WHERE organization_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetMenusByOrganizationID(ctx context.Context, organizationID *uuid.UUID) ([]Menu, error) {
+	rows, err := q.db.Query(ctx, getMenusByOrganizationID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Menu
+	for rows.Next() {
+		var i Menu
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.IsActive,
+			&i.RestaurantID,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMenusByRestaurantID = `-- name: GetMenusByRestaurantID :many
+SELECT m.id, m.created_at, m.updated_at, m.name, m.is_active, m.restaurant_id, m.organization_id
+FROM menus m
+WHERE m.restaurant_id = $1::uuid
+OR m.organization_id = (SELECT r.organization_id FROM restaurants r WHERE r.id = $1::uuid)
+ORDER BY m.organization_id NULLS FIRST, m.id
+`
+
+func (q *Queries) GetMenusByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]Menu, error) {
+	rows, err := q.db.Query(ctx, getMenusByRestaurantID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Menu
+	for rows.Next() {
+		var i Menu
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.IsActive,
+			&i.RestaurantID,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationMenuByID = `-- name: GetOrganizationMenuByID :one
+SELECT id, created_at, updated_at, name, is_active, restaurant_id, organization_id FROM menus
+WHERE id = $1 AND organization_id = $2
+`
+
+type GetOrganizationMenuByIDParams struct {
+	ID             int32
+	OrganizationID *uuid.UUID
+}
+
+func (q *Queries) GetOrganizationMenuByID(ctx context.Context, arg GetOrganizationMenuByIDParams) (Menu, error) {
+	row := q.db.QueryRow(ctx, getOrganizationMenuByID, arg.ID, arg.OrganizationID)
+	var i Menu
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.IsActive,
+		&i.RestaurantID,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -47,7 +169,7 @@ LIMIT 1
 )
 `
 
-func (q *Queries) MenuExistsForRestaurantID(ctx context.Context, restaurantID uuid.UUID) (bool, error) {
+func (q *Queries) MenuExistsForRestaurantID(ctx context.Context, restaurantID *uuid.UUID) (bool, error) {
 	row := q.db.QueryRow(ctx, menuExistsForRestaurantID, restaurantID)
 	var exists bool
 	err := row.Scan(&exists)
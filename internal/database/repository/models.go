@@ -17,7 +17,34 @@ type Article struct {
 	Price        float64
 	ArticleOrder int16
 	CategoryID   int32
+	RestaurantID *uuid.UUID
+}
+
+type ArticlePriceOverride struct {
+	ArticleID    int32
+	RestaurantID uuid.UUID
+	Price        float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ArticleUnavailability struct {
+	ArticleID    int32
 	RestaurantID uuid.UUID
+	CreatedAt    time.Time
+}
+
+type AuditLog struct {
+	ID            int64
+	RestaurantID  uuid.UUID
+	ActorUserID   *uuid.UUID
+	Action        string
+	EntityType    string
+	EntityID      string
+	BeforeData    []byte
+	AfterData     []byte
+	CreatedAt     time.Time
+	ActorApiKeyID *int32
 }
 
 type Category struct {
@@ -26,32 +53,66 @@ type Category struct {
 	Description   *string
 	CategoryOrder int16
 	MenuID        int32
-	RestaurantID  uuid.UUID
+	RestaurantID  *uuid.UUID
 }
 
 type Menu struct {
-	ID           int32
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Name         string
-	IsActive     bool
-	RestaurantID uuid.UUID
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	IsActive       bool
+	RestaurantID   *uuid.UUID
+	OrganizationID *uuid.UUID
+}
+
+type Organization struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+}
+
+type OrganizationUser struct {
+	ID             int32
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	RoleID         int32
+	CreatedAt      time.Time
 }
 
 type Restaurant struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Name        string
-	Alias       string
-	Description *string
-	Address     string
-	Lat         *float64
-	Lng         *float64
-	Phone       *string
-	ImageUrl    *string
-	IsVerified  bool
-	PlaceID     string
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Name              string
+	Alias             string
+	Description       *string
+	Address           string
+	Lat               *float64
+	Lng               *float64
+	Phone             *string
+	ImageUrl          *string
+	IsVerified        bool
+	PlaceID           string
+	Timezone          string
+	OwnerEditedFields []string
+	PlaceSyncedAt     *time.Time
+	OrganizationID    *uuid.UUID
+}
+
+type RestaurantApiKey struct {
+	ID              int32
+	RestaurantID    uuid.UUID
+	Name            string
+	Prefix          string
+	SecretHash      string
+	Scopes          []string
+	CreatedByUserID *uuid.UUID
+	ExpiresAt       *time.Time
+	LastUsedAt      *time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
 }
 
 type RestaurantInvite struct {
@@ -60,23 +121,99 @@ type RestaurantInvite struct {
 	InvitedByUserID  uuid.UUID
 	CanceledByUserID *uuid.UUID
 	Email            string
-	RoleID           int16
+	RoleID           int32
 	AcceptedAt       *time.Time
 	CanceledAt       *time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
 
+type RestaurantOpeningHour struct {
+	ID           int32
+	RestaurantID uuid.UUID
+	DayOfWeek    int16
+	OpensAt      int16
+	ClosesAt     int16
+}
+
+type RestaurantOpeningHourException struct {
+	ID           int32
+	RestaurantID uuid.UUID
+	Date         time.Time
+	OpensAt      *int16
+	ClosesAt     *int16
+	Reason       *string
+	CreatedAt    time.Time
+}
+
+type RestaurantOwnershipTransfer struct {
+	ID                int32
+	RestaurantID      uuid.UUID
+	FromUserID        *uuid.UUID
+	ToUserID          *uuid.UUID
+	Status            string
+	FromUserNewRoleID *int32
+	CompletedAt       *time.Time
+	CanceledAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type RestaurantPlaceUpdate struct {
+	ID               int32
+	RestaurantID     uuid.UUID
+	Field            string
+	OldValue         *string
+	NewValue         *string
+	Status           string
+	ResolvedByUserID *uuid.UUID
+	ResolvedAt       *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type RestaurantSetting struct {
+	RestaurantID     uuid.UUID
+	Currency         string
+	Locale           string
+	PricesIncludeTax bool
+	DefaultTaxRate   float64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
 type RestaurantUser struct {
 	ID           int32
 	RestaurantID uuid.UUID
 	UserID       uuid.UUID
-	RoleID       int16
+	RoleID       int32
+}
+
+type RestaurantVerification struct {
+	ID                int32
+	RestaurantID      uuid.UUID
+	RequestedByUserID *uuid.UUID
+	ReviewedByUserID  *uuid.UUID
+	Method            string
+	Status            string
+	BusinessEmail     *string
+	DocumentUrl       *string
+	RejectionReason   *string
+	ProofVerifiedAt   *time.Time
+	ReviewedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type Role struct {
-	ID   int16
-	Name string
+	ID           int32
+	Name         string
+	RestaurantID *uuid.UUID
+}
+
+type RolePermission struct {
+	RoleID     int32
+	Permission string
 }
 
 type User struct {
@@ -88,4 +225,35 @@ type User struct {
 	Password        string
 	IsEmailVerified bool
 	AvatarUrl       *string
+	IsAdmin         bool
+}
+
+type UserDeletion struct {
+	UserID      uuid.UUID
+	RequestedAt time.Time
+	DeleteAfter time.Time
+}
+
+type UserIdentity struct {
+	ID        int32
+	UserID    uuid.UUID
+	Provider  string
+	Subject   string
+	CreatedAt time.Time
+}
+
+type UserRecoveryCode struct {
+	ID        int32
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep *int64
+	CreatedAt    time.Time
 }
@@ -7,25 +7,27 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createRestaurant = `-- name: CreateRestaurant :one
 INSERT INTO restaurants
-(name, alias, address, lat, lng, phone, place_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id
+(name, alias, address, lat, lng, phone, place_id, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id
 `
 
 type CreateRestaurantParams struct {
-	Name    string
-	Alias   string
-	Address string
-	Lat     *float64
-	Lng     *float64
-	Phone   *string
-	PlaceID string
+	Name     string
+	Alias    string
+	Address  string
+	Lat      *float64
+	Lng      *float64
+	Phone    *string
+	PlaceID  string
+	Timezone string
 }
 
 func (q *Queries) CreateRestaurant(ctx context.Context, arg CreateRestaurantParams) (Restaurant, error) {
@@ -37,6 +39,7 @@ func (q *Queries) CreateRestaurant(ctx context.Context, arg CreateRestaurantPara
 		arg.Lng,
 		arg.Phone,
 		arg.PlaceID,
+		arg.Timezone,
 	)
 	var i Restaurant
 	err := row.Scan(
@@ -53,12 +56,151 @@ func (q *Queries) CreateRestaurant(ctx context.Context, arg CreateRestaurantPara
 		&i.ImageUrl,
 		&i.IsVerified,
 		&i.PlaceID,
+		&i.Timezone,
+		&i.OwnerEditedFields,
+		&i.PlaceSyncedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
+const getCompetingRestaurantClaimUsers = `-- name: GetCompetingRestaurantClaimUsers :many
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, u.id AS user_id, u.name AS user_name, u.email
+FROM restaurants r
+INNER JOIN restaurant_users ru ON ru.restaurant_id = r.id
+INNER JOIN users u ON u.id = ru.user_id
+WHERE r.place_id = $1
+AND r.id <> $2
+AND r.is_verified = FALSE
+`
+
+type GetCompetingRestaurantClaimUsersParams struct {
+	PlaceID string
+	ID      uuid.UUID
+}
+
+type GetCompetingRestaurantClaimUsersRow struct {
+	RestaurantID   uuid.UUID
+	RestaurantName string
+	UserID         uuid.UUID
+	UserName       string
+	Email          string
+}
+
+func (q *Queries) GetCompetingRestaurantClaimUsers(ctx context.Context, arg GetCompetingRestaurantClaimUsersParams) ([]GetCompetingRestaurantClaimUsersRow, error) {
+	rows, err := q.db.Query(ctx, getCompetingRestaurantClaimUsers, arg.PlaceID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompetingRestaurantClaimUsersRow
+	for rows.Next() {
+		var i GetCompetingRestaurantClaimUsersRow
+		if err := rows.Scan(
+			&i.RestaurantID,
+			&i.RestaurantName,
+			&i.UserID,
+			&i.UserName,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNearbyRestaurants = `-- name: GetNearbyRestaurants :many
+SELECT r.id, r.created_at, r.updated_at, r.name, r.alias, r.description, r.address, r.lat, r.lng, r.phone, r.image_url, r.is_verified, r.place_id, r.timezone, r.owner_edited_fields, r.place_synced_at, r.organization_id, earth_distance(ll_to_earth($1::float, $2::float), ll_to_earth(r.lat, r.lng))::float AS distance
+FROM restaurants r
+WHERE r.is_verified = TRUE
+AND r.lat IS NOT NULL
+AND r.lng IS NOT NULL
+AND earth_box(ll_to_earth($1::float, $2::float), $3::float) @> ll_to_earth(r.lat, r.lng)
+AND earth_distance(ll_to_earth($1::float, $2::float), ll_to_earth(r.lat, r.lng)) <= $3::float
+ORDER BY distance
+LIMIT $4 OFFSET $5
+`
+
+type GetNearbyRestaurantsParams struct {
+	Lat        float64
+	Lng        float64
+	Radius     float64
+	PageSize   int32
+	PageOffset int32
+}
+
+type GetNearbyRestaurantsRow struct {
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Name              string
+	Alias             string
+	Description       *string
+	Address           string
+	Lat               *float64
+	Lng               *float64
+	Phone             *string
+	ImageUrl          *string
+	IsVerified        bool
+	PlaceID           string
+	Timezone          string
+	OwnerEditedFields []string
+	PlaceSyncedAt     *time.Time
+	OrganizationID    *uuid.UUID
+	Distance          float64
+}
+
+func (q *Queries) GetNearbyRestaurants(ctx context.Context, arg GetNearbyRestaurantsParams) ([]GetNearbyRestaurantsRow, error) {
+	rows, err := q.db.Query(ctx, getNearbyRestaurants,
+		arg.Lat,
+		arg.Lng,
+		arg.Radius,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNearbyRestaurantsRow
+	for rows.Next() {
+		var i GetNearbyRestaurantsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Alias,
+			&i.Description,
+			&i.Address,
+			&i.Lat,
+			&i.Lng,
+			&i.Phone,
+			&i.ImageUrl,
+			&i.IsVerified,
+			&i.PlaceID,
+			&i.Timezone,
+			&i.OwnerEditedFields,
+			&i.PlaceSyncedAt,
+			&i.OrganizationID,
+			&i.Distance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRestaurantByID = `-- name: GetRestaurantByID :one
-SELECT id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id FROM restaurants WHERE id = $1
+SELECT id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id FROM restaurants WHERE id = $1
 `
 
 func (q *Queries) GetRestaurantByID(ctx context.Context, id uuid.UUID) (Restaurant, error) {
@@ -78,15 +220,94 @@ func (q *Queries) GetRestaurantByID(ctx context.Context, id uuid.UUID) (Restaura
 		&i.ImageUrl,
 		&i.IsVerified,
 		&i.PlaceID,
+		&i.Timezone,
+		&i.OwnerEditedFields,
+		&i.PlaceSyncedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
+const getRestaurantByIDForUpdate = `-- name: GetRestaurantByIDForUpdate :one
+SELECT id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id FROM restaurants
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetRestaurantByIDForUpdate(ctx context.Context, id uuid.UUID) (Restaurant, error) {
+	row := q.db.QueryRow(ctx, getRestaurantByIDForUpdate, id)
+	var i Restaurant
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Alias,
+		&i.Description,
+		&i.Address,
+		&i.Lat,
+		&i.Lng,
+		&i.Phone,
+		&i.ImageUrl,
+		&i.IsVerified,
+		&i.PlaceID,
+		&i.Timezone,
+		&i.OwnerEditedFields,
+		&i.PlaceSyncedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getRestaurantsByOrganizationID = `-- name: GetRestaurantsByOrganizationID :many
+SELECT id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id FROM restaurants
+WHERE organization_id = $1
+ORDER BY name
+`
+
+func (q *Queries) GetRestaurantsByOrganizationID(ctx context.Context, organizationID *uuid.UUID) ([]Restaurant, error) {
+	rows, err := q.db.Query(ctx, getRestaurantsByOrganizationID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Restaurant
+	for rows.Next() {
+		var i Restaurant
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Alias,
+			&i.Description,
+			&i.Address,
+			&i.Lat,
+			&i.Lng,
+			&i.Phone,
+			&i.ImageUrl,
+			&i.IsVerified,
+			&i.PlaceID,
+			&i.Timezone,
+			&i.OwnerEditedFields,
+			&i.PlaceSyncedAt,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRestaurantsByUserID = `-- name: GetRestaurantsByUserID :many
-SELECT r.id, r.created_at, r.updated_at, r.name, r.alias, r.description, r.address, r.lat, r.lng, r.phone, r.image_url, r.is_verified, r.place_id
+SELECT r.id, r.created_at, r.updated_at, r.name, r.alias, r.description, r.address, r.lat, r.lng, r.phone, r.image_url, r.is_verified, r.place_id, r.timezone, r.owner_edited_fields, r.place_synced_at, r.organization_id
 FROM restaurants r
-LEFT JOIN restaurant_users ru ON ru.restaurant_id = r.id
-WHERE ru.user_id = $1
+WHERE r.id IN (SELECT restaurant_id FROM restaurant_users WHERE user_id = $1)
+OR r.organization_id IN (SELECT organization_id FROM organization_users WHERE user_id = $1)
 `
 
 func (q *Queries) GetRestaurantsByUserID(ctx context.Context, userID uuid.UUID) ([]Restaurant, error) {
@@ -112,6 +333,60 @@ func (q *Queries) GetRestaurantsByUserID(ctx context.Context, userID uuid.UUID)
 			&i.ImageUrl,
 			&i.IsVerified,
 			&i.PlaceID,
+			&i.Timezone,
+			&i.OwnerEditedFields,
+			&i.PlaceSyncedAt,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRestaurantsDueForPlaceSync = `-- name: GetRestaurantsDueForPlaceSync :many
+SELECT id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id FROM restaurants
+WHERE place_synced_at IS NULL OR place_synced_at < $1::timestamp
+ORDER BY place_synced_at NULLS FIRST
+LIMIT $2
+`
+
+type GetRestaurantsDueForPlaceSyncParams struct {
+	SyncedBefore time.Time
+	BatchSize    int32
+}
+
+func (q *Queries) GetRestaurantsDueForPlaceSync(ctx context.Context, arg GetRestaurantsDueForPlaceSyncParams) ([]Restaurant, error) {
+	rows, err := q.db.Query(ctx, getRestaurantsDueForPlaceSync, arg.SyncedBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Restaurant
+	for rows.Next() {
+		var i Restaurant
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Alias,
+			&i.Description,
+			&i.Address,
+			&i.Lat,
+			&i.Lng,
+			&i.Phone,
+			&i.ImageUrl,
+			&i.IsVerified,
+			&i.PlaceID,
+			&i.Timezone,
+			&i.OwnerEditedFields,
+			&i.PlaceSyncedAt,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -123,6 +398,28 @@ func (q *Queries) GetRestaurantsByUserID(ctx context.Context, userID uuid.UUID)
 	return items, nil
 }
 
+const isPlaceVerifiedByAnotherRestaurant = `-- name: IsPlaceVerifiedByAnotherRestaurant :one
+SELECT EXISTS (
+    SELECT 1
+    FROM restaurants
+    WHERE place_id = $1
+    AND id <> $2
+    AND is_verified = TRUE
+)
+`
+
+type IsPlaceVerifiedByAnotherRestaurantParams struct {
+	PlaceID string
+	ID      uuid.UUID
+}
+
+func (q *Queries) IsPlaceVerifiedByAnotherRestaurant(ctx context.Context, arg IsPlaceVerifiedByAnotherRestaurantParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isPlaceVerifiedByAnotherRestaurant, arg.PlaceID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const isRestaurantAlreadyTaken = `-- name: IsRestaurantAlreadyTaken :one
 SELECT EXISTS (
     SELECT 1
@@ -146,3 +443,153 @@ func (q *Queries) IsRestaurantAlreadyTaken(ctx context.Context, arg IsRestaurant
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const lockRestaurant = `-- name: LockRestaurant :exec
+SELECT id FROM restaurants
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockRestaurant(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockRestaurant, id)
+	return err
+}
+
+const lockRestaurantsByPlaceID = `-- name: LockRestaurantsByPlaceID :exec
+SELECT id FROM restaurants
+WHERE place_id = $1
+ORDER BY id
+FOR UPDATE
+`
+
+func (q *Queries) LockRestaurantsByPlaceID(ctx context.Context, placeID string) error {
+	_, err := q.db.Exec(ctx, lockRestaurantsByPlaceID, placeID)
+	return err
+}
+
+const setRestaurantOrganization = `-- name: SetRestaurantOrganization :exec
+UPDATE restaurants
+SET organization_id = $1
+WHERE id = $2
+`
+
+type SetRestaurantOrganizationParams struct {
+	OrganizationID *uuid.UUID
+	ID             uuid.UUID
+}
+
+func (q *Queries) SetRestaurantOrganization(ctx context.Context, arg SetRestaurantOrganizationParams) error {
+	_, err := q.db.Exec(ctx, setRestaurantOrganization, arg.OrganizationID, arg.ID)
+	return err
+}
+
+const setRestaurantPlaceSynced = `-- name: SetRestaurantPlaceSynced :exec
+UPDATE restaurants
+SET place_synced_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) SetRestaurantPlaceSynced(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setRestaurantPlaceSynced, id)
+	return err
+}
+
+const setRestaurantVerified = `-- name: SetRestaurantVerified :exec
+UPDATE restaurants
+SET is_verified = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) SetRestaurantVerified(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setRestaurantVerified, id)
+	return err
+}
+
+const updateRestaurantDetails = `-- name: UpdateRestaurantDetails :one
+UPDATE restaurants
+SET name = $1, address = $2, phone = $3, owner_edited_fields = $4
+WHERE id = $5
+RETURNING id, created_at, updated_at, name, alias, description, address, lat, lng, phone, image_url, is_verified, place_id, timezone, owner_edited_fields, place_synced_at, organization_id
+`
+
+type UpdateRestaurantDetailsParams struct {
+	Name              string
+	Address           string
+	Phone             *string
+	OwnerEditedFields []string
+	ID                uuid.UUID
+}
+
+func (q *Queries) UpdateRestaurantDetails(ctx context.Context, arg UpdateRestaurantDetailsParams) (Restaurant, error) {
+	row := q.db.QueryRow(ctx, updateRestaurantDetails,
+		arg.Name,
+		arg.Address,
+		arg.Phone,
+		arg.OwnerEditedFields,
+		arg.ID,
+	)
+	var i Restaurant
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Alias,
+		&i.Description,
+		&i.Address,
+		&i.Lat,
+		&i.Lng,
+		&i.Phone,
+		&i.ImageUrl,
+		&i.IsVerified,
+		&i.PlaceID,
+		&i.Timezone,
+		&i.OwnerEditedFields,
+		&i.PlaceSyncedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const updateRestaurantPlaceDetails = `-- name: UpdateRestaurantPlaceDetails :exec
+UPDATE restaurants
+SET name = $1, address = $2, lat = $3, lng = $4, phone = $5
+WHERE id = $6
+`
+
+type UpdateRestaurantPlaceDetailsParams struct {
+	Name    string
+	Address string
+	Lat     *float64
+	Lng     *float64
+	Phone   *string
+	ID      uuid.UUID
+}
+
+func (q *Queries) UpdateRestaurantPlaceDetails(ctx context.Context, arg UpdateRestaurantPlaceDetailsParams) error {
+	_, err := q.db.Exec(ctx, updateRestaurantPlaceDetails,
+		arg.Name,
+		arg.Address,
+		arg.Lat,
+		arg.Lng,
+		arg.Phone,
+		arg.ID,
+	)
+	return err
+}
+
+const updateRestaurantTimezone = `-- name: UpdateRestaurantTimezone :exec
+UPDATE restaurants
+SET timezone = $1
+WHERE id = $2
+`
+
+type UpdateRestaurantTimezoneParams struct {
+	Timezone string
+	ID       uuid.UUID
+}
+
+func (q *Queries) UpdateRestaurantTimezone(ctx context.Context, arg UpdateRestaurantTimezoneParams) error {
+	_, err := q.db.Exec(ctx, updateRestaurantTimezone, arg.Timezone, arg.ID)
+	return err
+}
@@ -19,7 +19,7 @@ VALUES ($1, $2, $3)
 type AddRestaurantUserParams struct {
 	UserID       uuid.UUID
 	RestaurantID uuid.UUID
-	RoleID       int16
+	RoleID       int32
 }
 
 func (q *Queries) AddRestaurantUser(ctx context.Context, arg AddRestaurantUserParams) error {
@@ -27,24 +27,162 @@ func (q *Queries) AddRestaurantUser(ctx context.Context, arg AddRestaurantUserPa
 	return err
 }
 
-const getAnyRestaurantUserLinkByUserID = `-- name: GetAnyRestaurantUserLinkByUserID :one
-SELECT id, restaurant_id, user_id, role_id FROM restaurant_users
-WHERE user_id = $1
+const countRestaurantUsersByRoleID = `-- name: CountRestaurantUsersByRoleID :one
+SELECT COUNT(*) FROM restaurant_users
+WHERE restaurant_id = $1 AND role_id = $2
+`
+
+type CountRestaurantUsersByRoleIDParams struct {
+	RestaurantID uuid.UUID
+	RoleID       int32
+}
+
+func (q *Queries) CountRestaurantUsersByRoleID(ctx context.Context, arg CountRestaurantUsersByRoleIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRestaurantUsersByRoleID, arg.RestaurantID, arg.RoleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteRestaurantUser = `-- name: DeleteRestaurantUser :execrows
+DELETE FROM restaurant_users
+WHERE restaurant_id = $1 AND user_id = $2
+`
+
+type DeleteRestaurantUserParams struct {
+	RestaurantID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) DeleteRestaurantUser(ctx context.Context, arg DeleteRestaurantUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRestaurantUser, arg.RestaurantID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRestaurantUsersByRestaurantID = `-- name: DeleteRestaurantUsersByRestaurantID :exec
+DELETE FROM restaurant_users
+WHERE restaurant_id = $1
+`
+
+func (q *Queries) DeleteRestaurantUsersByRestaurantID(ctx context.Context, restaurantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRestaurantUsersByRestaurantID, restaurantID)
+	return err
+}
+
+const getAnyRestaurantAccessByUserID = `-- name: GetAnyRestaurantAccessByUserID :one
+SELECT ru.restaurant_id, ru.role_id
+FROM restaurant_users ru
+WHERE ru.user_id = $1
+UNION ALL
+SELECT r.id AS restaurant_id, ou.role_id
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+WHERE ou.user_id = $1
 LIMIT 1
 `
 
-func (q *Queries) GetAnyRestaurantUserLinkByUserID(ctx context.Context, userID uuid.UUID) (RestaurantUser, error) {
-	row := q.db.QueryRow(ctx, getAnyRestaurantUserLinkByUserID, userID)
-	var i RestaurantUser
+type GetAnyRestaurantAccessByUserIDRow struct {
+	RestaurantID uuid.UUID
+	RoleID       int32
+}
+
+func (q *Queries) GetAnyRestaurantAccessByUserID(ctx context.Context, userID uuid.UUID) (GetAnyRestaurantAccessByUserIDRow, error) {
+	row := q.db.QueryRow(ctx, getAnyRestaurantAccessByUserID, userID)
+	var i GetAnyRestaurantAccessByUserIDRow
 	err := row.Scan(
-		&i.ID,
 		&i.RestaurantID,
-		&i.UserID,
 		&i.RoleID,
 	)
 	return i, err
 }
 
+const getEffectiveRestaurantUserRoleID = `-- name: GetEffectiveRestaurantUserRoleID :one
+SELECT role_id FROM (
+    SELECT ru.role_id, 1 AS precedence
+    FROM restaurant_users ru
+    WHERE ru.restaurant_id = $1 AND ru.user_id = $2
+    UNION ALL
+    SELECT ou.role_id, 2 AS precedence
+    FROM organization_users ou
+    INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+    WHERE r.id = $1 AND ou.user_id = $2
+) roles
+ORDER BY precedence
+LIMIT 1
+`
+
+type GetEffectiveRestaurantUserRoleIDParams struct {
+	RestaurantID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) GetEffectiveRestaurantUserRoleID(ctx context.Context, arg GetEffectiveRestaurantUserRoleIDParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getEffectiveRestaurantUserRoleID, arg.RestaurantID, arg.UserID)
+	var role_id int32
+	err := row.Scan(&role_id)
+	return role_id, err
+}
+
+const getRestaurantMembers = `-- name: GetRestaurantMembers :many
+SELECT u.id AS user_id, u.name, u.email, u.avatar_url, ru.role_id, ro.name AS role_name, FALSE AS is_inherited
+FROM restaurant_users ru
+INNER JOIN users u ON u.id = ru.user_id
+INNER JOIN roles ro ON ro.id = ru.role_id
+WHERE ru.restaurant_id = $1
+UNION ALL
+SELECT u.id AS user_id, u.name, u.email, u.avatar_url, ou.role_id, ro.name AS role_name, TRUE AS is_inherited
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+INNER JOIN users u ON u.id = ou.user_id
+INNER JOIN roles ro ON ro.id = ou.role_id
+WHERE r.id = $1 AND NOT EXISTS (
+    SELECT 1 FROM restaurant_users ru
+    WHERE ru.restaurant_id = r.id AND ru.user_id = ou.user_id
+)
+ORDER BY role_id, name
+`
+
+type GetRestaurantMembersRow struct {
+	UserID      uuid.UUID
+	Name        string
+	Email       string
+	AvatarUrl   *string
+	RoleID      int32
+	RoleName    string
+	IsInherited bool
+}
+
+func (q *Queries) GetRestaurantMembers(ctx context.Context, restaurantID uuid.UUID) ([]GetRestaurantMembersRow, error) {
+	rows, err := q.db.Query(ctx, getRestaurantMembers, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRestaurantMembersRow
+	for rows.Next() {
+		var i GetRestaurantMembersRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Name,
+			&i.Email,
+			&i.AvatarUrl,
+			&i.RoleID,
+			&i.RoleName,
+			&i.IsInherited,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRestaurantUserRoleID = `-- name: GetRestaurantUserRoleID :one
 SELECT role_id FROM restaurant_users 
 WHERE restaurant_id = $1 AND user_id = $2
@@ -55,9 +193,80 @@ type GetRestaurantUserRoleIDParams struct {
 	UserID       uuid.UUID
 }
 
-func (q *Queries) GetRestaurantUserRoleID(ctx context.Context, arg GetRestaurantUserRoleIDParams) (int16, error) {
+func (q *Queries) GetRestaurantUserRoleID(ctx context.Context, arg GetRestaurantUserRoleIDParams) (int32, error) {
 	row := q.db.QueryRow(ctx, getRestaurantUserRoleID, arg.RestaurantID, arg.UserID)
-	var role_id int16
+	var role_id int32
 	err := row.Scan(&role_id)
 	return role_id, err
 }
+
+const getUserRestaurantRoles = `-- name: GetUserRestaurantRoles :many
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, r.alias AS restaurant_alias, ru.role_id, ro.name AS role_name, FALSE AS is_inherited
+FROM restaurant_users ru
+INNER JOIN restaurants r ON r.id = ru.restaurant_id
+INNER JOIN roles ro ON ro.id = ru.role_id
+WHERE ru.user_id = $1
+UNION ALL
+SELECT r.id AS restaurant_id, r.name AS restaurant_name, r.alias AS restaurant_alias, ou.role_id, ro.name AS role_name, TRUE AS is_inherited
+FROM organization_users ou
+INNER JOIN restaurants r ON r.organization_id = ou.organization_id
+INNER JOIN roles ro ON ro.id = ou.role_id
+WHERE ou.user_id = $1 AND NOT EXISTS (
+    SELECT 1 FROM restaurant_users ru
+    WHERE ru.restaurant_id = r.id AND ru.user_id = ou.user_id
+)
+ORDER BY restaurant_name
+`
+
+type GetUserRestaurantRolesRow struct {
+	RestaurantID    uuid.UUID
+	RestaurantName  string
+	RestaurantAlias string
+	RoleID          int32
+	RoleName        string
+	IsInherited     bool
+}
+
+func (q *Queries) GetUserRestaurantRoles(ctx context.Context, userID uuid.UUID) ([]GetUserRestaurantRolesRow, error) {
+	rows, err := q.db.Query(ctx, getUserRestaurantRoles, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserRestaurantRolesRow
+	for rows.Next() {
+		var i GetUserRestaurantRolesRow
+		if err := rows.Scan(
+			&i.RestaurantID,
+			&i.RestaurantName,
+			&i.RestaurantAlias,
+			&i.RoleID,
+			&i.RoleName,
+			&i.IsInherited,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateRestaurantUserRole = `-- name: UpdateRestaurantUserRole :exec
+UPDATE restaurant_users
+SET role_id = $1
+WHERE restaurant_id = $2 AND user_id = $3
+`
+
+type UpdateRestaurantUserRoleParams struct {
+	RoleID       int32
+	RestaurantID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) UpdateRestaurantUserRole(ctx context.Context, arg UpdateRestaurantUserRoleParams) error {
+	_, err := q.db.Exec(ctx, updateRestaurantUserRole, arg.RoleID, arg.RestaurantID, arg.UserID)
+	return err
+}
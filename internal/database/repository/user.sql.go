@@ -12,7 +12,7 @@ import (
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at, name, email, password, is_email_verified, avatar_url
+INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at, name, email, password, is_email_verified, avatar_url, is_admin
 `
 
 type CreateUserParams struct {
@@ -33,12 +33,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Password,
 		&i.IsEmailVerified,
 		&i.AvatarUrl,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, name, email, password, is_email_verified, avatar_url FROM users WHERE email = $1
+SELECT id, created_at, updated_at, name, email, password, is_email_verified, avatar_url, is_admin FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -53,12 +54,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Password,
 		&i.IsEmailVerified,
 		&i.AvatarUrl,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, name, email, password, is_email_verified, avatar_url FROM users WHERE id = $1
+SELECT id, created_at, updated_at, name, email, password, is_email_verified, avatar_url, is_admin FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
@@ -73,26 +75,53 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Password,
 		&i.IsEmailVerified,
 		&i.AvatarUrl,
+		&i.IsAdmin,
+	)
+	return i, err
+}
+
+const getVerifiedUserByEmail = `-- name: GetVerifiedUserByEmail :one
+SELECT id, created_at, updated_at, name, email, password, is_email_verified, avatar_url, is_admin FROM users WHERE email = $1 AND is_email_verified = TRUE
+`
+
+func (q *Queries) GetVerifiedUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getVerifiedUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.Password,
+		&i.IsEmailVerified,
+		&i.AvatarUrl,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = $1, is_email_verified = $2, avatar_url= $3
-WHERE id = $4
-RETURNING id, created_at, updated_at, name, email, password, is_email_verified, avatar_url
+SET name = COALESCE($1, name),
+    email = COALESCE($2, email),
+    is_email_verified = COALESCE($3, is_email_verified),
+    avatar_url = CASE WHEN $4::varchar IS NULL THEN avatar_url ELSE NULLIF($4, '') END
+WHERE id = $5
+RETURNING id, created_at, updated_at, name, email, password, is_email_verified, avatar_url, is_admin
 `
 
 type UpdateUserParams struct {
-	Email           string
-	IsEmailVerified bool
+	Name            *string
+	Email           *string
+	IsEmailVerified *bool
 	AvatarUrl       *string
 	ID              uuid.UUID
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, updateUser,
+		arg.Name,
 		arg.Email,
 		arg.IsEmailVerified,
 		arg.AvatarUrl,
@@ -108,10 +137,25 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Password,
 		&i.IsEmailVerified,
 		&i.AvatarUrl,
+		&i.IsAdmin,
 	)
 	return i, err
 }
 
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password = $1 WHERE id = $2
+`
+
+type UpdateUserPasswordParams struct {
+	Password string
+	ID       uuid.UUID
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.Password, arg.ID)
+	return err
+}
+
 const userEmailTaken = `-- name: UserEmailTaken :one
 SELECT EXISTS(
   SELECT 1 FROM users
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type APIKey struct {
+	ID              int               `json:"id"`
+	RestaurantID    uuid.UUID         `json:"restaurant_id"`
+	Name            string            `json:"name"`
+	Prefix          string            `json:"prefix"`
+	Scopes          []enum.Permission `json:"scopes"`
+	CreatedByUserID *uuid.UUID        `json:"created_by_user_id"`
+	ExpiresAt       *time.Time        `json:"expires_at"`
+	LastUsedAt      *time.Time        `json:"last_used_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func NewAPIKey(apiKey *repository.RestaurantApiKey) *APIKey {
+	scopes := make([]enum.Permission, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		scopes[i] = enum.Permission(scope)
+	}
+
+	return &APIKey{
+		ID:              int(apiKey.ID),
+		RestaurantID:    apiKey.RestaurantID,
+		Name:            apiKey.Name,
+		Prefix:          apiKey.Prefix,
+		Scopes:          scopes,
+		CreatedByUserID: apiKey.CreatedByUserID,
+		ExpiresAt:       apiKey.ExpiresAt,
+		LastUsedAt:      apiKey.LastUsedAt,
+		CreatedAt:       apiKey.CreatedAt,
+	}
+}
+
+// CreatedAPIKey holds the full key, it is only returned once when the key is created.
+type CreatedAPIKey struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
+
+type CreateAPIKey struct {
+	Name      string
+	Scopes    []enum.Permission
+	ExpiresAt *time.Time
+}
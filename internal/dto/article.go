@@ -1,16 +1,77 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
 
 type Article struct {
-	ID           int        `json:"id"`
-	Name         string     `json:"name"`
-	Description  string     `json:"description"`
-	Price        float64    `json:"price"`
-	ImageURL     *string    `json:"image_url"`
-	ArticleOrder int        `json:"article_order"`
-	CategoryID   string     `json:"category_id"`
-	RestaurantID uuid.UUID  `json:"restaurant_id"`
-	Category     Category   `json:"category"`
-	Restaurant   Restaurant `json:"restaurant"`
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Price        float64     `json:"price"`
+	BasePrice    *float64    `json:"base_price,omitempty"`
+	IsAvailable  bool        `json:"is_available"`
+	ImageURL     *string     `json:"image_url"`
+	ArticleOrder int         `json:"article_order"`
+	CategoryID   int         `json:"category_id"`
+	RestaurantID *uuid.UUID  `json:"restaurant_id"`
+	Category     *Category   `json:"category,omitempty"`
+	Restaurant   *Restaurant `json:"restaurant,omitempty"`
+}
+
+func NewArticle(article *repository.Article) *Article {
+	return &Article{
+		ID:           int(article.ID),
+		Name:         article.Name,
+		Description:  article.Description,
+		Price:        article.Price,
+		IsAvailable:  true,
+		ArticleOrder: int(article.ArticleOrder),
+		CategoryID:   int(article.CategoryID),
+		RestaurantID: article.RestaurantID,
+	}
+}
+
+// NewRestaurantArticle returns the article with the restaurant price, BasePrice keeps the menu price when overridden.
+func NewRestaurantArticle(article *repository.GetArticlesByMenuIDsForRestaurantRow) *Article {
+	a := &Article{
+		ID:           int(article.ID),
+		Name:         article.Name,
+		Description:  article.Description,
+		Price:        article.Price,
+		IsAvailable:  article.IsAvailable,
+		ArticleOrder: int(article.ArticleOrder),
+		CategoryID:   int(article.CategoryID),
+		RestaurantID: article.RestaurantID,
+	}
+
+	if article.OverridePrice != nil {
+		basePrice := article.Price
+		a.BasePrice = &basePrice
+		a.Price = *article.OverridePrice
+	}
+
+	return a
+}
+
+type CreateArticle struct {
+	Name         string
+	Description  string
+	Price        float64
+	ArticleOrder int
+}
+
+type ArticlePriceOverride struct {
+	ArticleID    int       `json:"article_id"`
+	RestaurantID uuid.UUID `json:"restaurant_id"`
+	Price        float64   `json:"price"`
+}
+
+func NewArticlePriceOverride(override *repository.ArticlePriceOverride) *ArticlePriceOverride {
+	return &ArticlePriceOverride{
+		ArticleID:    int(override.ArticleID),
+		RestaurantID: override.RestaurantID,
+		Price:        override.Price,
+	}
 }
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type AuditLog struct {
+	ID            int64            `json:"id"`
+	ActorUserID   *uuid.UUID       `json:"actor_user_id"`
+	ActorAPIKeyID *int             `json:"actor_api_key_id"`
+	Action        enum.AuditAction `json:"action"`
+	EntityType    enum.AuditEntity `json:"entity_type"`
+	EntityID      string           `json:"entity_id"`
+	Before        json.RawMessage  `json:"before"`
+	After         json.RawMessage  `json:"after"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+func NewAuditLog(log *repository.AuditLog) *AuditLog {
+	auditLog := &AuditLog{
+		ID:          log.ID,
+		ActorUserID: log.ActorUserID,
+		Action:      enum.AuditAction(log.Action),
+		EntityType:  enum.AuditEntity(log.EntityType),
+		EntityID:    log.EntityID,
+		Before:      log.BeforeData,
+		After:       log.AfterData,
+		CreatedAt:   log.CreatedAt,
+	}
+	if log.ActorApiKeyID != nil {
+		apiKeyID := int(*log.ActorApiKeyID)
+		auditLog.ActorAPIKeyID = &apiKeyID
+	}
+	return auditLog
+}
+
+// AuditLogPage holds a page of entries, NextCursor is nil on the last page.
+type AuditLogPage struct {
+	Entries    []*AuditLog `json:"entries"`
+	NextCursor *int64      `json:"next_cursor"`
+}
+
+// CreateAuditLog describes a change, Before and After are stored as JSON and left empty when nil.
+// The actor is read from the request context when ActorUserID is nil, an API key is recorded when there is no user.
+type CreateAuditLog struct {
+	RestaurantID uuid.UUID
+	ActorUserID  *uuid.UUID
+	Action       enum.AuditAction
+	EntityType   enum.AuditEntity
+	EntityID     string
+	Before       any
+	After        any
+}
+
+// AuditLogFilter narrows the entries of a restaurant, Cursor is the ID of the last entry already seen.
+type AuditLogFilter struct {
+	RestaurantID uuid.UUID
+	ActorUserID  *uuid.UUID
+	EntityType   *enum.AuditEntity
+	From         *time.Time
+	To           *time.Time
+	Cursor       *int64
+	Limit        int
+}
+
+func (f AuditLogFilter) ToParams() repository.GetAuditLogsByRestaurantIDParams {
+	params := repository.GetAuditLogsByRestaurantIDParams{
+		RestaurantID: f.RestaurantID,
+		ActorUserID:  f.ActorUserID,
+		CreatedFrom:  f.From,
+		CreatedTo:    f.To,
+		Cursor:       f.Cursor,
+		// One more entry tells whether another page follows
+		PageSize: int32(f.Limit + 1),
+	}
+	if f.EntityType != nil {
+		entityType := string(*f.EntityType)
+		params.EntityType = &entityType
+	}
+	return params
+}
@@ -3,4 +3,6 @@ package dto
 type LoginResponse struct {
 	User        *User         `json:"user"`
 	Restaurants []*Restaurant `json:"restaurants"`
+	// ChallengeToken replaces the session when the user still has to verify a second factor
+	ChallengeToken string `json:"-"`
 }
@@ -1,16 +1,37 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
 
 type Category struct {
-	ID            int        `json:"id"`
-	Name          string     `json:"name"`
-	Description   *string    `json:"description"`
-	CategoryOrder int        `json:"category_order"`
-	IsDefault     bool       `json:"is_default"`
-	MenuID        int        `json:"menu_id"`
-	RestaurantID  uuid.UUID  `json:"restaurant_id"`
-	Menu          Menu       `json:"menu"`
-	Restaurant    Restaurant `json:"restaurant"`
-	Articles      []Article  `json:"articles"`
+	ID            int         `json:"id"`
+	Name          string      `json:"name"`
+	Description   *string     `json:"description"`
+	CategoryOrder int         `json:"category_order"`
+	IsDefault     bool        `json:"is_default"`
+	MenuID        int         `json:"menu_id"`
+	RestaurantID  *uuid.UUID  `json:"restaurant_id"`
+	Menu          *Menu       `json:"menu,omitempty"`
+	Restaurant    *Restaurant `json:"restaurant,omitempty"`
+	Articles      []Article   `json:"articles"`
+}
+
+func NewCategory(category *repository.Category) *Category {
+	return &Category{
+		ID:            int(category.ID),
+		Name:          category.Name,
+		Description:   category.Description,
+		CategoryOrder: int(category.CategoryOrder),
+		MenuID:        int(category.MenuID),
+		RestaurantID:  category.RestaurantID,
+		Articles:      []Article{},
+	}
+}
+
+type CreateCategory struct {
+	Name          string
+	Description   *string
+	CategoryOrder int
 }
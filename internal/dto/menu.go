@@ -8,22 +8,25 @@ import (
 )
 
 type Menu struct {
-	ID           int         `json:"id"`
-	CreatedAt    time.Time   `json:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at"`
-	Name         string      `json:"name"`
-	IsActive     bool        `json:"is_active"`
-	RestaurantID uuid.UUID   `json:"restaurant_id"`
-	Restaurant   *Restaurant `json:"restaurant,omitempty"`
+	ID             int         `json:"id"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	Name           string      `json:"name"`
+	IsActive       bool        `json:"is_active"`
+	RestaurantID   *uuid.UUID  `json:"restaurant_id"`
+	OrganizationID *uuid.UUID  `json:"organization_id"`
+	Restaurant     *Restaurant `json:"restaurant,omitempty"`
+	Categories     []Category  `json:"categories,omitempty"`
 }
 
 func NewMenu(menu *repository.Menu) *Menu {
 	return &Menu{
-		ID:           int(menu.ID),
-		CreatedAt:    menu.CreatedAt,
-		UpdatedAt:    menu.UpdatedAt,
-		Name:         menu.Name,
-		IsActive:     menu.IsActive,
-		RestaurantID: menu.RestaurantID,
+		ID:             int(menu.ID),
+		CreatedAt:      menu.CreatedAt,
+		UpdatedAt:      menu.UpdatedAt,
+		Name:           menu.Name,
+		IsActive:       menu.IsActive,
+		RestaurantID:   menu.RestaurantID,
+		OrganizationID: menu.OrganizationID,
 	}
 }
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/memsbdm/restaurant-api/internal/database/enum"
+
+// OIDCIdentity is the user described by a verified ID token.
+type OIDCIdentity struct {
+	Provider      enum.IdentityProvider
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     *string
+}
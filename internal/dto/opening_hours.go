@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+const DateLayout = "2006-01-02"
+
+var ErrInvalidClock = errors.New("invalid clock time, expected HH:MM")
+
+type OpeningHours struct {
+	Timezone   string                 `json:"timezone"`
+	Weekly     []OpeningHour          `json:"weekly"`
+	Exceptions []OpeningHourException `json:"exceptions"`
+}
+
+type OpeningHour struct {
+	ID        int    `json:"id"`
+	DayOfWeek int    `json:"day_of_week"`
+	OpensAt   string `json:"opens_at"`
+	ClosesAt  string `json:"closes_at"`
+}
+
+func NewOpeningHour(openingHour *repository.RestaurantOpeningHour) *OpeningHour {
+	return &OpeningHour{
+		ID:        int(openingHour.ID),
+		DayOfWeek: int(openingHour.DayOfWeek),
+		OpensAt:   FormatClock(openingHour.OpensAt),
+		ClosesAt:  FormatClock(openingHour.ClosesAt),
+	}
+}
+
+type OpeningHourException struct {
+	ID       int     `json:"id"`
+	Date     string  `json:"date"`
+	IsClosed bool    `json:"is_closed"`
+	OpensAt  *string `json:"opens_at"`
+	ClosesAt *string `json:"closes_at"`
+	Reason   *string `json:"reason"`
+}
+
+func NewOpeningHourException(exception *repository.RestaurantOpeningHourException) *OpeningHourException {
+	e := &OpeningHourException{
+		ID:       int(exception.ID),
+		Date:     exception.Date.Format(DateLayout),
+		IsClosed: exception.OpensAt == nil,
+		Reason:   exception.Reason,
+	}
+
+	if exception.OpensAt != nil && exception.ClosesAt != nil {
+		opensAt := FormatClock(*exception.OpensAt)
+		closesAt := FormatClock(*exception.ClosesAt)
+		e.OpensAt = &opensAt
+		e.ClosesAt = &closesAt
+	}
+
+	return e
+}
+
+// TimeRange is an opening range expressed in minutes since midnight.
+// A range where ClosesAt <= OpensAt runs overnight.
+type TimeRange struct {
+	OpensAt  int16
+	ClosesAt int16
+}
+
+type UpdateOpeningHours struct {
+	Timezone string
+	Weekly   map[time.Weekday][]TimeRange
+}
+
+type CreateOpeningHourException struct {
+	Date   time.Time
+	Range  *TimeRange
+	Reason *string
+}
+
+// ParseClock converts an "HH:MM" string into minutes since midnight.
+// "24:00" is accepted as the end of the day.
+func ParseClock(clock string) (int16, error) {
+	var hours, minutes int
+	if len(clock) != 5 {
+		return 0, ErrInvalidClock
+	}
+	if _, err := fmt.Sscanf(clock, "%02d:%02d", &hours, &minutes); err != nil {
+		return 0, ErrInvalidClock
+	}
+	if minutes < 0 || minutes > 59 || hours < 0 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, ErrInvalidClock
+	}
+
+	return int16(hours*60 + minutes), nil
+}
+
+func FormatClock(minutes int16) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
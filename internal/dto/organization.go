@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+}
+
+func NewOrganization(organization *repository.Organization) *Organization {
+	return &Organization{
+		ID:        organization.ID,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+		Name:      organization.Name,
+	}
+}
+
+type OrganizationMember struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	RoleID    int       `json:"role_id"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewOrganizationMember(member *repository.GetOrganizationMembersRow) *OrganizationMember {
+	return &OrganizationMember{
+		UserID:    member.UserID,
+		Name:      member.Name,
+		Email:     member.Email,
+		RoleID:    int(member.RoleID),
+		Role:      Role{ID: int(member.RoleID), Name: member.RoleName},
+		CreatedAt: member.CreatedAt,
+	}
+}
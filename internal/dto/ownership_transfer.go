@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type OwnershipTransfer struct {
+	ID                int        `json:"id"`
+	RestaurantID      uuid.UUID  `json:"restaurant_id"`
+	FromUserID        *uuid.UUID `json:"from_user_id"`
+	ToUserID          *uuid.UUID `json:"to_user_id"`
+	Status            string     `json:"status"`
+	FromUserNewRoleID *int       `json:"from_user_new_role_id"`
+	CompletedAt       *time.Time `json:"completed_at"`
+	CanceledAt        *time.Time `json:"canceled_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func NewOwnershipTransfer(transfer *repository.RestaurantOwnershipTransfer) *OwnershipTransfer {
+	t := &OwnershipTransfer{
+		ID:           int(transfer.ID),
+		RestaurantID: transfer.RestaurantID,
+		FromUserID:   transfer.FromUserID,
+		ToUserID:     transfer.ToUserID,
+		Status:       transfer.Status,
+		CompletedAt:  transfer.CompletedAt,
+		CanceledAt:   transfer.CanceledAt,
+		CreatedAt:    transfer.CreatedAt,
+		UpdatedAt:    transfer.UpdatedAt,
+	}
+
+	if transfer.FromUserNewRoleID != nil {
+		roleID := int(*transfer.FromUserNewRoleID)
+		t.FromUserNewRoleID = &roleID
+	}
+
+	return t
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type PlaceUpdate struct {
+	ID               int        `json:"id"`
+	RestaurantID     uuid.UUID  `json:"restaurant_id"`
+	Field            string     `json:"field"`
+	OldValue         *string    `json:"old_value"`
+	NewValue         *string    `json:"new_value"`
+	Status           string     `json:"status"`
+	ResolvedByUserID *uuid.UUID `json:"resolved_by_user_id"`
+	ResolvedAt       *time.Time `json:"resolved_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func NewPlaceUpdate(update *repository.RestaurantPlaceUpdate) *PlaceUpdate {
+	return &PlaceUpdate{
+		ID:               int(update.ID),
+		RestaurantID:     update.RestaurantID,
+		Field:            update.Field,
+		OldValue:         update.OldValue,
+		NewValue:         update.NewValue,
+		Status:           update.Status,
+		ResolvedByUserID: update.ResolvedByUserID,
+		ResolvedAt:       update.ResolvedAt,
+		CreatedAt:        update.CreatedAt,
+		UpdatedAt:        update.UpdatedAt,
+	}
+}
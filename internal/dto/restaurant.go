@@ -8,23 +8,28 @@ import (
 )
 
 type Restaurant struct {
-	ID          uuid.UUID          `json:"id"`
-	CreatedAt   time.Time          `json:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at"`
-	Name        string             `json:"name"`
-	Alias       string             `json:"alias"`
-	Description *string            `json:"description"`
-	Address     string             `json:"address"`
-	Lat         *float64           `json:"lat"`
-	Lng         *float64           `json:"lng"`
-	Phone       *string            `json:"phone"`
-	ImageURL    *string            `json:"image_url"`
-	IsVerified  bool               `json:"is_verified"`
-	PlaceID     string             `json:"place_id"`
-	Menus       []Menu             `json:"menus,omitempty"`
-	Categories  []Category         `json:"categories,omitempty"`
-	Articles    []Article          `json:"articles,omitempty"`
-	Invites     []RestaurantInvite `json:"invites,omitempty"`
+	ID           uuid.UUID          `json:"id"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	Name         string             `json:"name"`
+	Alias        string             `json:"alias"`
+	Description  *string            `json:"description"`
+	Address      string             `json:"address"`
+	Lat          *float64           `json:"lat"`
+	Lng          *float64           `json:"lng"`
+	Phone        *string            `json:"phone"`
+	ImageURL     *string            `json:"image_url"`
+	IsVerified   bool               `json:"is_verified"`
+	PlaceID      string             `json:"place_id"`
+	Timezone     string             `json:"timezone"`
+	Distance     *float64           `json:"distance,omitempty"`
+	IsOpenNow    *bool              `json:"is_open_now,omitempty"`
+	NextChangeAt *time.Time         `json:"next_change_at,omitempty"`
+	OpeningHours *OpeningHours      `json:"opening_hours,omitempty"`
+	Menus        []Menu             `json:"menus,omitempty"`
+	Categories   []Category         `json:"categories,omitempty"`
+	Articles     []Article          `json:"articles,omitempty"`
+	Invites      []RestaurantInvite `json:"invites,omitempty"`
 }
 
 func NewRestaurant(restaurant *repository.Restaurant) *Restaurant {
@@ -36,31 +41,83 @@ func NewRestaurant(restaurant *repository.Restaurant) *Restaurant {
 		Alias:       restaurant.Alias,
 		Description: restaurant.Description,
 		Address:     restaurant.Address,
+		Lat:         restaurant.Lat,
+		Lng:         restaurant.Lng,
 		Phone:       restaurant.Phone,
 		ImageURL:    restaurant.ImageUrl,
 		IsVerified:  restaurant.IsVerified,
 		PlaceID:     restaurant.PlaceID,
+		Timezone:    restaurant.Timezone,
 	}
 }
 
-type CreateRestaurant struct {
-	Name    string
-	Alias   string
-	Address string
-	Lat     *float64
-	Lng     *float64
+func NewNearbyRestaurant(restaurant *repository.GetNearbyRestaurantsRow) *Restaurant {
+	return &Restaurant{
+		ID:          restaurant.ID,
+		CreatedAt:   restaurant.CreatedAt,
+		UpdatedAt:   restaurant.UpdatedAt,
+		Name:        restaurant.Name,
+		Alias:       restaurant.Alias,
+		Description: restaurant.Description,
+		Address:     restaurant.Address,
+		Lat:         restaurant.Lat,
+		Lng:         restaurant.Lng,
+		Phone:       restaurant.Phone,
+		ImageURL:    restaurant.ImageUrl,
+		IsVerified:  restaurant.IsVerified,
+		PlaceID:     restaurant.PlaceID,
+		Timezone:    restaurant.Timezone,
+		Distance:    &restaurant.Distance,
+	}
+}
+
+// NearbyRestaurantsFilter holds a search around a point, Radius is in meters.
+type NearbyRestaurantsFilter struct {
+	Lat     float64
+	Lng     float64
+	Radius  float64
+	Page    int
+	PerPage int
+}
+
+func (f NearbyRestaurantsFilter) ToParams() repository.GetNearbyRestaurantsParams {
+	return repository.GetNearbyRestaurantsParams{
+		Lat:        f.Lat,
+		Lng:        f.Lng,
+		Radius:     f.Radius,
+		PageSize:   int32(f.PerPage),
+		PageOffset: int32((f.Page - 1) * f.PerPage),
+	}
+}
+
+// UpdateRestaurant holds the owner changes, nil fields are left untouched.
+type UpdateRestaurant struct {
+	Name    *string
+	Address *string
 	Phone   *string
-	PlaceID string
+}
+
+type CreateRestaurant struct {
+	Name        string
+	Alias       string
+	Address     string
+	Lat         *float64
+	Lng         *float64
+	Phone       *string
+	PlaceID     string
+	Timezone    string
+	CountryCode string
 }
 
 func (r CreateRestaurant) ToParams() repository.CreateRestaurantParams {
 	return repository.CreateRestaurantParams{
-		Name:    r.Name,
-		Alias:   r.Alias,
-		Address: r.Address,
-		Lat:     r.Lat,
-		Lng:     r.Lng,
-		Phone:   r.Phone,
-		PlaceID: r.PlaceID,
+		Name:     r.Name,
+		Alias:    r.Alias,
+		Address:  r.Address,
+		Lat:      r.Lat,
+		Lng:      r.Lng,
+		Phone:    r.Phone,
+		PlaceID:  r.PlaceID,
+		Timezone: r.Timezone,
 	}
 }
@@ -4,21 +4,70 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
 )
 
 type RestaurantInvite struct {
-	ID               int        `json:"id"`
-	InvitedByUserID  uuid.UUID  `json:"invited_by_user_id"`
-	CanceledByUserID *uuid.UUID `json:"canceled_by_user_id"`
-	RoleID           int        `json:"role_id"`
-	Email            string     `json:"email"`
-	CreatedAt        time.Time  `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
-	AcceptedAt       *time.Time `json:"accepted_at"`
-	CanceledAt       *time.Time `json:"canceled_at"`
-	InvitedByUser    User       `json:"invited_by_user"`
-	CanceledByUser   *User      `json:"canceled_by_user"`
-	Role             Role       `json:"role"`
-	RestaurantID     uuid.UUID  `json:"restaurant_id"`
-	Restaurant       Restaurant `json:"restaurant"`
+	ID               int         `json:"id"`
+	InvitedByUserID  uuid.UUID   `json:"invited_by_user_id"`
+	CanceledByUserID *uuid.UUID  `json:"canceled_by_user_id"`
+	RoleID           int         `json:"role_id"`
+	Email            string      `json:"email"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+	AcceptedAt       *time.Time  `json:"accepted_at"`
+	CanceledAt       *time.Time  `json:"canceled_at"`
+	InvitedByUser    *User       `json:"invited_by_user,omitempty"`
+	CanceledByUser   *User       `json:"canceled_by_user,omitempty"`
+	Role             *Role       `json:"role,omitempty"`
+	RestaurantID     uuid.UUID   `json:"restaurant_id"`
+	Restaurant       *Restaurant `json:"restaurant,omitempty"`
+}
+
+func NewRestaurantInvite(invite *repository.RestaurantInvite) *RestaurantInvite {
+	return &RestaurantInvite{
+		ID:               int(invite.ID),
+		InvitedByUserID:  invite.InvitedByUserID,
+		CanceledByUserID: invite.CanceledByUserID,
+		RoleID:           int(invite.RoleID),
+		Email:            invite.Email,
+		CreatedAt:        invite.CreatedAt,
+		UpdatedAt:        invite.UpdatedAt,
+		AcceptedAt:       invite.AcceptedAt,
+		CanceledAt:       invite.CanceledAt,
+		RestaurantID:     invite.RestaurantID,
+	}
+}
+
+// NewPendingRestaurantInvite returns the invite with its role name for team listings.
+func NewPendingRestaurantInvite(invite *repository.GetPendingRestaurantInvitesByRestaurantIDRow) *RestaurantInvite {
+	return &RestaurantInvite{
+		ID:               int(invite.ID),
+		InvitedByUserID:  invite.InvitedByUserID,
+		CanceledByUserID: invite.CanceledByUserID,
+		RoleID:           int(invite.RoleID),
+		Email:            invite.Email,
+		CreatedAt:        invite.CreatedAt,
+		UpdatedAt:        invite.UpdatedAt,
+		AcceptedAt:       invite.AcceptedAt,
+		CanceledAt:       invite.CanceledAt,
+		Role:             &Role{ID: int(invite.RoleID), Name: invite.RoleName},
+		RestaurantID:     invite.RestaurantID,
+	}
+}
+
+type CreateRestaurantInvite struct {
+	RestaurantID    uuid.UUID
+	InvitedByUserID uuid.UUID
+	Email           string
+	RoleID          int
+}
+
+func (i CreateRestaurantInvite) ToParams() repository.CreateRestaurantInviteParams {
+	return repository.CreateRestaurantInviteParams{
+		RestaurantID:    i.RestaurantID,
+		InvitedByUserID: i.InvitedByUserID,
+		Email:           i.Email,
+		RoleID:          int32(i.RoleID),
+	}
 }
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type RestaurantSettings struct {
+	RestaurantID     uuid.UUID `json:"restaurant_id"`
+	Timezone         string    `json:"timezone"`
+	Currency         string    `json:"currency"`
+	Locale           string    `json:"locale"`
+	PricesIncludeTax bool      `json:"prices_include_tax"`
+	DefaultTaxRate   float64   `json:"default_tax_rate"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func NewRestaurantSettings(settings *repository.RestaurantSetting, timezone string) *RestaurantSettings {
+	return &RestaurantSettings{
+		RestaurantID:     settings.RestaurantID,
+		Timezone:         timezone,
+		Currency:         settings.Currency,
+		Locale:           settings.Locale,
+		PricesIncludeTax: settings.PricesIncludeTax,
+		DefaultTaxRate:   settings.DefaultTaxRate,
+		UpdatedAt:        settings.UpdatedAt,
+	}
+}
+
+// UpdateRestaurantSettings holds the settings to change, nil fields are left untouched.
+type UpdateRestaurantSettings struct {
+	Timezone         *string
+	Currency         *string
+	Locale           *string
+	PricesIncludeTax *bool
+	DefaultTaxRate   *float64
+}
@@ -20,3 +20,25 @@ func NewRestaurantUser(restaurantUser *repository.RestaurantUser) *RestaurantUse
 		RoleID:       int(restaurantUser.RoleID),
 	}
 }
+
+type RestaurantMember struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	AvatarURL   *string   `json:"avatar_url"`
+	RoleID      int       `json:"role_id"`
+	Role        Role      `json:"role"`
+	IsInherited bool      `json:"is_inherited"`
+}
+
+func NewRestaurantMember(member *repository.GetRestaurantMembersRow) *RestaurantMember {
+	return &RestaurantMember{
+		UserID:      member.UserID,
+		Name:        member.Name,
+		Email:       member.Email,
+		AvatarURL:   member.AvatarUrl,
+		RoleID:      int(member.RoleID),
+		Role:        Role{ID: int(member.RoleID), Name: member.RoleName},
+		IsInherited: member.IsInherited,
+	}
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+type RestaurantVerification struct {
+	ID                int         `json:"id"`
+	RestaurantID      uuid.UUID   `json:"restaurant_id"`
+	RequestedByUserID *uuid.UUID  `json:"requested_by_user_id"`
+	ReviewedByUserID  *uuid.UUID  `json:"reviewed_by_user_id"`
+	Method            string      `json:"method"`
+	Status            string      `json:"status"`
+	BusinessEmail     *string     `json:"business_email"`
+	DocumentURL       *string     `json:"document_url"`
+	RejectionReason   *string     `json:"rejection_reason"`
+	ProofVerifiedAt   *time.Time  `json:"proof_verified_at"`
+	ReviewedAt        *time.Time  `json:"reviewed_at"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+	Restaurant        *Restaurant `json:"restaurant,omitempty"`
+}
+
+func NewRestaurantVerification(verification *repository.RestaurantVerification) *RestaurantVerification {
+	return &RestaurantVerification{
+		ID:                int(verification.ID),
+		RestaurantID:      verification.RestaurantID,
+		RequestedByUserID: verification.RequestedByUserID,
+		ReviewedByUserID:  verification.ReviewedByUserID,
+		Method:            verification.Method,
+		Status:            verification.Status,
+		BusinessEmail:     verification.BusinessEmail,
+		DocumentURL:       verification.DocumentUrl,
+		RejectionReason:   verification.RejectionReason,
+		ProofVerifiedAt:   verification.ProofVerifiedAt,
+		ReviewedAt:        verification.ReviewedAt,
+		CreatedAt:         verification.CreatedAt,
+		UpdatedAt:         verification.UpdatedAt,
+	}
+}
+
+type CreateRestaurantVerification struct {
+	RestaurantID  uuid.UUID
+	UserID        uuid.UUID
+	Method        enum.VerificationMethod
+	BusinessEmail *string
+	DocumentURL   *string
+}
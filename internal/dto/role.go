@@ -1,6 +1,28 @@
 package dto
 
+import (
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
 type Role struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	RestaurantID *uuid.UUID        `json:"restaurant_id,omitempty"`
+	Permissions  []enum.Permission `json:"permissions,omitempty"`
+}
+
+func NewRole(role *repository.Role, permissions []enum.Permission) *Role {
+	return &Role{
+		ID:           int(role.ID),
+		Name:         role.Name,
+		RestaurantID: role.RestaurantID,
+		Permissions:  permissions,
+	}
+}
+
+type SaveRole struct {
+	Name        string
+	Permissions []enum.Permission
 }
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	ClientType string    `json:"client_type"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+// SessionClient describes the client a request comes from.
+type SessionClient struct {
+	ClientType string
+	UserAgent  string
+	IP         string
+}
+
+// RefreshTokenFamily groups the refresh tokens rotated from a single login, only the latest one is usable.
+type RefreshTokenFamily struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Token     string    `json:"token"`
+	OAT       string    `json:"oat"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -16,6 +16,7 @@ type User struct {
 	Password        string    `json:"-"`
 	IsEmailVerified bool      `json:"is_email_verified"`
 	AvatarURL       *string   `json:"avatar_url"`
+	IsAdmin         bool      `json:"is_admin"`
 }
 
 func NewUser(user *repository.User) *User {
@@ -28,15 +29,49 @@ func NewUser(user *repository.User) *User {
 		Password:        user.Password,
 		IsEmailVerified: user.IsEmailVerified,
 		AvatarURL:       user.AvatarUrl,
+		IsAdmin:         user.IsAdmin,
 	}
 }
 
-func (u *User) ToUpdateParams() repository.UpdateUserParams {
+// UpdateUser holds the fields to change, nil fields are left untouched and an empty AvatarURL removes the avatar.
+type UpdateUser struct {
+	Name            *string
+	Email           *string
+	IsEmailVerified *bool
+	AvatarURL       *string
+}
+
+func (u UpdateUser) ToParams(id uuid.UUID) repository.UpdateUserParams {
 	return repository.UpdateUserParams{
-		ID:              u.ID,
+		Name:            u.Name,
 		Email:           u.Email,
 		IsEmailVerified: u.IsEmailVerified,
 		AvatarUrl:       u.AvatarURL,
+		ID:              id,
+	}
+}
+
+type UserProfile struct {
+	User        *User             `json:"user"`
+	Restaurants []*UserRestaurant `json:"restaurants"`
+}
+
+// UserRestaurant is a restaurant the user has access to along with the role they hold in it.
+type UserRestaurant struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Alias       string    `json:"alias"`
+	Role        Role      `json:"role"`
+	IsInherited bool      `json:"is_inherited"`
+}
+
+func NewUserRestaurant(restaurant *repository.GetUserRestaurantRolesRow) *UserRestaurant {
+	return &UserRestaurant{
+		ID:          restaurant.RestaurantID,
+		Name:        restaurant.RestaurantName,
+		Alias:       restaurant.RestaurantAlias,
+		Role:        Role{ID: int(restaurant.RoleID), Name: restaurant.RoleName},
+		IsInherited: restaurant.IsInherited,
 	}
 }
 
@@ -53,3 +88,21 @@ func (u CreateUser) ToParams() repository.CreateUserParams {
 		Password: u.Password,
 	}
 }
+
+type AccountDeletion struct {
+	RequestedAt time.Time `json:"requested_at"`
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
+func NewAccountDeletion(deletion *repository.UserDeletion) *AccountDeletion {
+	return &AccountDeletion{
+		RequestedAt: deletion.RequestedAt,
+		DeleteAfter: deletion.DeleteAfter,
+	}
+}
+
+// SoleOwnedRestaurant is a restaurant that would be left without an owner if the user was deleted.
+type SoleOwnedRestaurant struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type APIKeyHandler struct {
+	apiKeySvc service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeySvc service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeySvc: apiKeySvc,
+	}
+}
+
+func (h *APIKeyHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	apiKeys, err := h.apiKeySvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, apiKeys)
+}
+
+type createAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"notblank,max=50"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Create returns the full key, it cannot be retrieved afterwards.
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request createAPIKeyRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	scopes := make([]enum.Permission, len(request.Scopes))
+	for i, scope := range request.Scopes {
+		scopes[i] = enum.Permission(strings.TrimSpace(scope))
+	}
+
+	apiKey, err := h.apiKeySvc.Create(ctx, restaurantID, userID, &dto.CreateAPIKey{
+		Name:      strings.TrimSpace(request.Name),
+		Scopes:    scopes,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, apiKey)
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	apiKeyID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.apiKeySvc.Revoke(r.Context(), restaurantID, apiKeyID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
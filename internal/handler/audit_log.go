@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+const defaultAuditLogLimit = 50
+
+type AuditLogHandler struct {
+	auditSvc service.AuditService
+}
+
+func NewAuditLogHandler(auditSvc service.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditSvc: auditSvc,
+	}
+}
+
+// auditLogRequest holds the query parameters, From and To are inclusive dates.
+type auditLogRequest struct {
+	Actor  string `validate:"omitempty,uuid"`
+	Entity string `validate:"omitempty,oneof=restaurant settings opening_hours opening_hour_exception verification ownership_transfer place_update invite member role menu category article api_key organization organization_member"`
+	From   string `validate:"omitempty,datetime=2006-01-02"`
+	To     string `validate:"omitempty,datetime=2006-01-02"`
+	Cursor int64  `validate:"gte=0"`
+	Limit  int    `validate:"min=1,max=100"`
+}
+
+func (h *AuditLogHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	request := auditLogRequest{
+		Actor:  query.Get("actor"),
+		Entity: query.Get("entity"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Limit:  defaultAuditLogLimit,
+	}
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			response.HandleError(w, response.ErrBadRequest)
+			return
+		}
+		request.Cursor = cursor
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			response.HandleError(w, response.ErrBadRequest)
+			return
+		}
+		request.Limit = limit
+	}
+
+	if errs := validation.ValidateStruct(&request); len(errs) != 0 {
+		response.HandleValidationError(w, errs, nil)
+		return
+	}
+
+	filter := dto.AuditLogFilter{
+		RestaurantID: restaurantID,
+		Limit:        request.Limit,
+	}
+	if request.Actor != "" {
+		actorUserID := uuid.MustParse(request.Actor)
+		filter.ActorUserID = &actorUserID
+	}
+	if request.Entity != "" {
+		entityType := enum.AuditEntity(request.Entity)
+		filter.EntityType = &entityType
+	}
+	if request.From != "" {
+		from, _ := time.Parse(dto.DateLayout, request.From)
+		filter.From = &from
+	}
+	if request.To != "" {
+		// The whole last day is included
+		to, _ := time.Parse(dto.DateLayout, request.To)
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if request.Cursor > 0 {
+		filter.Cursor = &request.Cursor
+	}
+
+	page, err := h.auditSvc.GetByRestaurantID(r.Context(), filter)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, page)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"crypto/subtle"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/memsbdm/restaurant-api/config"
@@ -15,12 +19,14 @@ import (
 type AuthHandler struct {
 	cfg     *config.App
 	authSvc service.AuthService
+	oidcSvc service.OIDCService
 }
 
-func NewAuthHandler(cfg *config.App, authSvc service.AuthService) *AuthHandler {
+func NewAuthHandler(cfg *config.App, authSvc service.AuthService, oidcSvc service.OIDCService) *AuthHandler {
 	return &AuthHandler{
 		cfg:     cfg,
 		authSvc: authSvc,
+		oidcSvc: oidcSvc,
 	}
 }
 
@@ -42,20 +48,31 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Name:     strings.TrimSpace(request.Name),
 		Email:    strings.TrimSpace(request.Email),
 		Password: request.Password,
-	})
+	}, NewSessionClient(r))
 	if err != nil {
 		response.HandleError(w, err)
 		return
 	}
 
 	if IsMobileRequest(r) {
+		refreshToken, err := h.authSvc.IssueRefreshToken(r.Context(), createdUser.ID, oat)
+		if err != nil {
+			response.HandleError(w, err)
+			return
+		}
+
 		response.HandleSuccess(w, http.StatusCreated, map[string]any{
-			"user":         createdUser,
-			"access_token": oat,
+			"user":          createdUser,
+			"access_token":  oat,
+			"refresh_token": refreshToken,
 		})
 		return
 	}
 
+	if err := IssueCSRFCookie(w, h.cfg.Env); err != nil {
+		response.HandleError(w, err)
+		return
+	}
 	SetAuthCookie(w, oat, h.cfg.Env)
 	response.HandleSuccess(w, http.StatusCreated, createdUser)
 }
@@ -73,41 +90,234 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	loginResponse, oat, err := h.authSvc.Login(r.Context(), strings.TrimSpace(request.Email), request.Password)
+	loginResponse, oat, err := h.authSvc.Login(r.Context(), strings.TrimSpace(request.Email), request.Password, NewSessionClient(r))
+	if err != nil {
+		handleLoginError(w, err)
+		return
+	}
+
+	h.writeLoginResponse(w, r, loginResponse, oat)
+}
+
+// GoogleLogin returns the URL of the Google consent screen the client has to open.
+func (h *AuthHandler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
+	authorizationURL, state, err := h.oidcSvc.AuthorizationURL(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	SetOIDCStateCookie(w, state, h.cfg.Env)
+
+	response.HandleSuccess(w, http.StatusOK, map[string]string{
+		"authorization_url": authorizationURL,
+	})
+}
+
+// GoogleCallback is where Google redirects after the consent screen.
+// The state has to match the cookie set by GoogleLogin, so a flow started by someone else cannot be completed.
+func (h *AuthHandler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	code, state := query.Get("code"), query.Get("state")
+	if code == "" || state == "" {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	cookie, err := r.Cookie(keys.OIDCStateCookieName)
+	clearOIDCStateCookie(w, h.cfg.Env)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		response.HandleError(w, service.ErrOIDCInvalidState)
+		return
+	}
+
+	loginResponse, oat, err := h.authSvc.LoginWithOIDC(r.Context(), code, state, NewSessionClient(r))
 	if err != nil {
 		response.HandleError(w, err)
 		return
 	}
 
+	h.writeLoginResponse(w, r, loginResponse, oat)
+}
+
+type verifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"notblank"`
+	Code           string `json:"code" validate:"notblank"`
+}
+
+// VerifyTwoFactor completes a login challenged for a second factor, the code is a TOTP or a recovery code.
+func (h *AuthHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var request verifyTwoFactorRequest
+
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	loginResponse, oat, err := h.authSvc.VerifyTwoFactor(r.Context(), request.ChallengeToken, request.Code, NewSessionClient(r))
+	if err != nil {
+		handleLoginError(w, err)
+		return
+	}
+
+	h.writeLoginResponse(w, r, loginResponse, oat)
+}
+
+// handleLoginError tells locked out clients when they can try again.
+func handleLoginError(w http.ResponseWriter, err error) {
+	var lockedErr *service.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		err = service.ErrLoginLocked
+	}
+	response.HandleError(w, err)
+}
+
+// writeLoginResponse hands the OAT and a refresh token to mobile clients, web clients get the auth cookie.
+func (h *AuthHandler) writeLoginResponse(w http.ResponseWriter, r *http.Request, loginResponse *dto.LoginResponse, oat string) {
+	if loginResponse.ChallengeToken != "" {
+		response.HandleSuccess(w, http.StatusOK, map[string]any{
+			"two_factor_required": true,
+			"challenge_token":     loginResponse.ChallengeToken,
+		})
+		return
+	}
+
 	if IsMobileRequest(r) {
+		refreshToken, err := h.authSvc.IssueRefreshToken(r.Context(), loginResponse.User.ID, oat)
+		if err != nil {
+			response.HandleError(w, err)
+			return
+		}
+
 		response.HandleSuccess(w, http.StatusCreated, map[string]any{
-			"user":         loginResponse.User,
-			"access_token": oat,
-			"restaurants":  loginResponse.Restaurants,
+			"user":          loginResponse.User,
+			"access_token":  oat,
+			"refresh_token": refreshToken,
+			"restaurants":   loginResponse.Restaurants,
 		})
 		return
 	}
 
+	if err := IssueCSRFCookie(w, h.cfg.Env); err != nil {
+		response.HandleError(w, err)
+		return
+	}
 	SetAuthCookie(w, oat, h.cfg.Env)
 	response.HandleSuccess(w, http.StatusCreated, loginResponse)
 }
 
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
 	oat, err := keys.GetValueFromContext(r.Context(), keys.AuthOATContextKey)
 	if err != nil {
 		response.HandleError(w, err)
 		return
 	}
 
-	err = h.authSvc.Logout(r.Context(), oat)
+	err = h.authSvc.Logout(r.Context(), userID, oat)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	if !IsMobileRequest(r) {
+		clearAuthCookie(w, h.cfg.Env)
+		clearCSRFCookie(w, h.cfg.Env)
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+type forgotPasswordRequest struct {
+	Email string `validate:"notblank,email"`
+}
+
+// ForgotPassword always answers 202 so the response does not reveal whether an account exists.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request forgotPasswordRequest
+
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	if err := h.authSvc.ForgotPassword(r.Context(), strings.TrimSpace(request.Email)); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusAccepted, nil)
+}
+
+type resetPasswordRequest struct {
+	Token    string `validate:"notblank"`
+	Password string `validate:"notblank,min=8"`
+}
+
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request resetPasswordRequest
+
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	if err := h.authSvc.ResetPassword(r.Context(), request.Token, request.Password); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+// LogoutEverywhere signs the user out of every session, including the current one.
+func (h *AuthHandler) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
 	if err != nil {
 		response.HandleError(w, err)
 		return
 	}
 
+	if err := h.authSvc.LogoutEverywhere(r.Context(), userID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
 	if !IsMobileRequest(r) {
 		clearAuthCookie(w, h.cfg.Env)
+		clearCSRFCookie(w, h.cfg.Env)
 	}
 
 	response.HandleSuccess(w, http.StatusNoContent, nil)
 }
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"notblank"`
+}
+
+// Refresh rotates the refresh token, replaying an already used one signs the whole token family out.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var request refreshTokenRequest
+
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	oat, refreshToken, err := h.authSvc.Refresh(r.Context(), request.RefreshToken, NewSessionClient(r))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, map[string]any{
+		"access_token":  oat,
+		"refresh_token": refreshToken,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/config"
 	"github.com/memsbdm/restaurant-api/pkg/keys"
+	"github.com/memsbdm/restaurant-api/pkg/security"
 )
 
 func SetActiveRestaurantCookie(w http.ResponseWriter, restaurantID uuid.UUID, appEnv string) {
@@ -21,6 +22,19 @@ func SetActiveRestaurantCookie(w http.ResponseWriter, restaurantID uuid.UUID, ap
 	http.SetCookie(w, cookie)
 }
 
+func ClearActiveRestaurantCookie(w http.ResponseWriter, appEnv string) {
+	cookie := &http.Cookie{
+		Name:     keys.ActiveRestaurantCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   appEnv == config.EnvProduction,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   -1,
+	}
+	http.SetCookie(w, cookie)
+}
+
 func SetAuthCookie(w http.ResponseWriter, oat, appEnv string) {
 	cookie := &http.Cookie{
 		Name:     keys.AuthOATCookieName,
@@ -47,3 +61,82 @@ func clearAuthCookie(w http.ResponseWriter, appEnv string) {
 	}
 	http.SetCookie(w, cookie)
 }
+
+// IssueCSRFCookie sets a fresh double-submit token for a new web session.
+// The cookie is readable by the client, which must echo it in the X-CSRF-Token header on unsafe requests.
+func IssueCSRFCookie(w http.ResponseWriter, appEnv string) error {
+	token, err := security.GenerateRandomString(32)
+	if err != nil {
+		return err
+	}
+
+	setCSRFCookie(w, token, appEnv)
+	return nil
+}
+
+// RefreshCSRFCookie resets the CSRF cookie expiration time alongside the auth cookie, keeping the current token
+// so that open tabs stay valid. Sessions without a token get a new one.
+func RefreshCSRFCookie(w http.ResponseWriter, r *http.Request, appEnv string) error {
+	cookie, err := r.Cookie(keys.CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return IssueCSRFCookie(w, appEnv)
+	}
+
+	setCSRFCookie(w, cookie.Value, appEnv)
+	return nil
+}
+
+func setCSRFCookie(w http.ResponseWriter, token, appEnv string) {
+	cookie := &http.Cookie{
+		Name:     keys.CSRFCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: false,
+		Secure:   appEnv == config.EnvProduction,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   60 * 60, // 1 hour
+	}
+
+	http.SetCookie(w, cookie)
+}
+
+func clearCSRFCookie(w http.ResponseWriter, appEnv string) {
+	cookie := &http.Cookie{
+		Name:     keys.CSRFCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: false,
+		Secure:   appEnv == config.EnvProduction,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   -1,
+	}
+	http.SetCookie(w, cookie)
+}
+
+// SetOIDCStateCookie binds the OIDC state to the browser starting the flow. The callback is a cross-site
+// redirection from the issuer, so the cookie has to be Lax to be sent with it.
+func SetOIDCStateCookie(w http.ResponseWriter, state, appEnv string) {
+	cookie := &http.Cookie{
+		Name:     keys.OIDCStateCookieName,
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   appEnv == config.EnvProduction,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(keys.OIDCStateDuration.Seconds()),
+	}
+	http.SetCookie(w, cookie)
+}
+
+func clearOIDCStateCookie(w http.ResponseWriter, appEnv string) {
+	cookie := &http.Cookie{
+		Name:     keys.OIDCStateCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   appEnv == config.EnvProduction,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	}
+	http.SetCookie(w, cookie)
+}
@@ -1,30 +1,95 @@
 package handler
 
 import (
+	"net"
 	"net/http"
 
 	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
 	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
 )
 
 type Handlers struct {
-	AuthHandler        *AuthHandler
-	GoogleHandler      *GoogleHandler
-	MenuHandler        *MenuHandler
-	RestaurantHandler  *RestaurantHandler
-	VerifyEmailHandler *VerifyEmailHandler
+	APIKeyHandler                 *APIKeyHandler
+	AuditLogHandler               *AuditLogHandler
+	AuthHandler                   *AuthHandler
+	GoogleHandler                 *GoogleHandler
+	MenuHandler                   *MenuHandler
+	OpeningHoursHandler           *OpeningHoursHandler
+	OrganizationHandler           *OrganizationHandler
+	OwnershipTransferHandler      *OwnershipTransferHandler
+	PlaceUpdateHandler            *PlaceUpdateHandler
+	RestaurantHandler             *RestaurantHandler
+	RestaurantInviteHandler       *RestaurantInviteHandler
+	RestaurantMemberHandler       *RestaurantMemberHandler
+	RestaurantSettingsHandler     *RestaurantSettingsHandler
+	RestaurantVerificationHandler *RestaurantVerificationHandler
+	RoleHandler                   *RoleHandler
+	UserHandler                   *UserHandler
+	VerifyEmailHandler            *VerifyEmailHandler
 }
 
 func New(cfg *config.Container, services *service.Services) *Handlers {
 	return &Handlers{
-		AuthHandler:        NewAuthHandler(cfg.App, services.AuthService),
-		GoogleHandler:      NewGoogleHandler(services.GoogleService),
-		MenuHandler:        NewMenuHandler(services.MenuService),
-		RestaurantHandler:  NewRestaurantHandler(cfg.App, services.RestaurantService),
-		VerifyEmailHandler: NewVerifyEmailHandler(services.UserService),
+		APIKeyHandler:                 NewAPIKeyHandler(services.APIKeyService),
+		AuditLogHandler:               NewAuditLogHandler(services.AuditService),
+		AuthHandler:                   NewAuthHandler(cfg.App, services.AuthService, services.OIDCService),
+		GoogleHandler:                 NewGoogleHandler(services.GoogleService),
+		MenuHandler:                   NewMenuHandler(services.MenuService),
+		OpeningHoursHandler:           NewOpeningHoursHandler(services.OpeningHoursService),
+		OrganizationHandler:           NewOrganizationHandler(services.OrganizationService),
+		OwnershipTransferHandler:      NewOwnershipTransferHandler(services.OwnershipTransferService),
+		PlaceUpdateHandler:            NewPlaceUpdateHandler(services.PlaceSyncService),
+		RestaurantHandler:             NewRestaurantHandler(cfg.App, services.RestaurantService),
+		RestaurantInviteHandler:       NewRestaurantInviteHandler(services.RestaurantInviteService),
+		RestaurantMemberHandler:       NewRestaurantMemberHandler(cfg.App, services.RestaurantUserService),
+		RestaurantSettingsHandler:     NewRestaurantSettingsHandler(services.RestaurantSettingsService),
+		RestaurantVerificationHandler: NewRestaurantVerificationHandler(services.RestaurantVerificationService),
+		RoleHandler:                   NewRoleHandler(services.RoleService, services.PermissionService),
+		UserHandler:                   NewUserHandler(cfg.App, services.AuthService, services.SessionService, services.TwoFactorService, services.UserService),
+		VerifyEmailHandler:            NewVerifyEmailHandler(services.UserService),
 	}
 }
 
 func IsMobileRequest(r *http.Request) bool {
 	return r.Header.Get("Client-Type") == "mobile"
 }
+
+// NewSessionClient describes the client of the request for the session inventory.
+func NewSessionClient(r *http.Request) *dto.SessionClient {
+	clientType := "web"
+	if IsMobileRequest(r) {
+		clientType = "mobile"
+	}
+
+	return &dto.SessionClient{
+		ClientType: clientType,
+		UserAgent:  r.UserAgent(),
+		IP:         clientIP(r),
+	}
+}
+
+// clientIP returns the IP resolved by ClientIPMiddleware, or the remote address.
+func clientIP(r *http.Request) string {
+	if ip, err := keys.GetValueFromContext(r.Context(), keys.ClientIPContextKey); err == nil {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// isOrganizationOwner reports whether the user owns the organization, it requires OrganizationMiddleware.
+func isOrganizationOwner(r *http.Request) bool {
+	roleID, err := keys.GetOrganizationRoleIDFromContext(r.Context())
+	if err != nil {
+		return false
+	}
+
+	return enum.RoleID(roleID) == enum.RoleOwner
+}
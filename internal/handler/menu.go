@@ -2,7 +2,10 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/memsbdm/restaurant-api/internal/dto"
 	"github.com/memsbdm/restaurant-api/internal/response"
 	"github.com/memsbdm/restaurant-api/internal/service"
 	"github.com/memsbdm/restaurant-api/internal/validation"
@@ -44,3 +47,238 @@ func (h *MenuHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	response.HandleSuccess(w, http.StatusCreated, menu)
 }
+
+func (h *MenuHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	menus, err := h.menuSvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, menus)
+}
+
+type setPriceOverrideRequest struct {
+	Price *float64 `json:"price" validate:"required,gte=0"`
+}
+
+func (h *MenuHandler) SetPriceOverride(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	articleID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request setPriceOverrideRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	override, err := h.menuSvc.SetPriceOverride(r.Context(), restaurantID, articleID, *request.Price)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, override)
+}
+
+func (h *MenuHandler) DeletePriceOverride(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	articleID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.menuSvc.DeletePriceOverride(r.Context(), restaurantID, articleID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+type setAvailabilityRequest struct {
+	IsAvailable *bool `json:"is_available" validate:"required"`
+}
+
+func (h *MenuHandler) SetAvailability(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	articleID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request setAvailabilityRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	if err := h.menuSvc.SetAvailability(r.Context(), restaurantID, articleID, *request.IsAvailable); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *MenuHandler) GetShared(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	menus, err := h.menuSvc.GetSharedByOrganizationID(r.Context(), organizationID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, menus)
+}
+
+type createSharedMenuRequest struct {
+	Name string `json:"name" validate:"notblank,max=50"`
+}
+
+func (h *MenuHandler) CreateShared(w http.ResponseWriter, r *http.Request) {
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request createSharedMenuRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	menu, err := h.menuSvc.CreateShared(r.Context(), strings.TrimSpace(request.Name), organizationID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, menu)
+}
+
+type createCategoryRequest struct {
+	Name          string  `json:"name" validate:"notblank,max=50"`
+	Description   *string `json:"description" validate:"omitempty,max=255"`
+	CategoryOrder int     `json:"category_order" validate:"gte=0"`
+}
+
+func (h *MenuHandler) CreateSharedCategory(w http.ResponseWriter, r *http.Request) {
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	menuID, err := strconv.Atoi(r.PathValue("menuID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request createCategoryRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	category, err := h.menuSvc.CreateSharedCategory(r.Context(), organizationID, menuID, &dto.CreateCategory{
+		Name:          strings.TrimSpace(request.Name),
+		Description:   request.Description,
+		CategoryOrder: request.CategoryOrder,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, category)
+}
+
+type createArticleRequest struct {
+	Name         string  `json:"name" validate:"notblank,max=50"`
+	Description  string  `json:"description" validate:"max=255"`
+	Price        float64 `json:"price" validate:"gte=0"`
+	ArticleOrder int     `json:"article_order" validate:"gte=0"`
+}
+
+func (h *MenuHandler) CreateSharedArticle(w http.ResponseWriter, r *http.Request) {
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	categoryID, err := strconv.Atoi(r.PathValue("categoryID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request createArticleRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	article, err := h.menuSvc.CreateSharedArticle(r.Context(), organizationID, categoryID, &dto.CreateArticle{
+		Name:         strings.TrimSpace(request.Name),
+		Description:  request.Description,
+		Price:        request.Price,
+		ArticleOrder: request.ArticleOrder,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, article)
+}
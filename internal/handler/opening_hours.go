@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type OpeningHoursHandler struct {
+	openingHoursSvc service.OpeningHoursService
+}
+
+func NewOpeningHoursHandler(openingHoursSvc service.OpeningHoursService) *OpeningHoursHandler {
+	return &OpeningHoursHandler{
+		openingHoursSvc: openingHoursSvc,
+	}
+}
+
+func (h *OpeningHoursHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	openingHours, err := h.openingHoursSvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, openingHours)
+}
+
+type openingHourRequest struct {
+	// DayOfWeek starts on Sunday (0) and ends on Saturday (6)
+	DayOfWeek *int   `json:"day_of_week" validate:"required,min=0,max=6"`
+	OpensAt   string `json:"opens_at" validate:"clock"`
+	ClosesAt  string `json:"closes_at" validate:"clock"`
+}
+
+type updateOpeningHoursRequest struct {
+	Timezone string               `json:"timezone" validate:"notblank,timezone"`
+	Weekly   []openingHourRequest `json:"weekly" validate:"dive"`
+}
+
+func (h *OpeningHoursHandler) Update(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request updateOpeningHoursRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	update := &dto.UpdateOpeningHours{
+		Timezone: request.Timezone,
+		Weekly:   make(map[time.Weekday][]dto.TimeRange),
+	}
+	for _, openingHour := range request.Weekly {
+		timeRange, err := parseTimeRange(openingHour.OpensAt, openingHour.ClosesAt)
+		if err != nil {
+			response.HandleError(w, response.ErrBadRequest)
+			return
+		}
+		day := time.Weekday(*openingHour.DayOfWeek)
+		update.Weekly[day] = append(update.Weekly[day], *timeRange)
+	}
+
+	openingHours, err := h.openingHoursSvc.Update(r.Context(), restaurantID, update)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, openingHours)
+}
+
+type createOpeningHourExceptionRequest struct {
+	Date     string  `json:"date" validate:"required,datetime=2006-01-02"`
+	OpensAt  *string `json:"opens_at" validate:"required_with=ClosesAt,omitempty,clock"`
+	ClosesAt *string `json:"closes_at" validate:"required_with=OpensAt,omitempty,clock"`
+	Reason   *string `json:"reason" validate:"omitempty,max=255"`
+}
+
+func (h *OpeningHoursHandler) CreateException(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request createOpeningHourExceptionRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	date, err := time.Parse(dto.DateLayout, request.Date)
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	exception := &dto.CreateOpeningHourException{
+		Date:   date,
+		Reason: request.Reason,
+	}
+	// Without a range the restaurant is closed for the whole day
+	if request.OpensAt != nil && request.ClosesAt != nil {
+		exception.Range, err = parseTimeRange(*request.OpensAt, *request.ClosesAt)
+		if err != nil {
+			response.HandleError(w, response.ErrBadRequest)
+			return
+		}
+	}
+
+	createdException, err := h.openingHoursSvc.CreateException(r.Context(), restaurantID, exception)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, createdException)
+}
+
+func (h *OpeningHoursHandler) DeleteException(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	exceptionID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	err = h.openingHoursSvc.DeleteException(r.Context(), restaurantID, exceptionID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func parseTimeRange(opensAt, closesAt string) (*dto.TimeRange, error) {
+	opens, err := dto.ParseClock(opensAt)
+	if err != nil {
+		return nil, err
+	}
+	closes, err := dto.ParseClock(closesAt)
+	if err != nil {
+		return nil, err
+	}
+	// 24:00 is only meaningful as a closing time
+	if opens == 24*60 {
+		return nil, dto.ErrInvalidClock
+	}
+
+	return &dto.TimeRange{OpensAt: opens, ClosesAt: closes}, nil
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type OrganizationHandler struct {
+	organizationSvc service.OrganizationService
+}
+
+func NewOrganizationHandler(organizationSvc service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationSvc: organizationSvc,
+	}
+}
+
+type createOrganizationRequest struct {
+	Name string `json:"name" validate:"notblank,max=50"`
+}
+
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request createOrganizationRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	organization, err := h.organizationSvc.Create(ctx, strings.TrimSpace(request.Name), userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, organization)
+}
+
+func (h *OrganizationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	organizations, err := h.organizationSvc.GetByUserID(r.Context(), userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, organizations)
+}
+
+func (h *OrganizationHandler) GetRestaurants(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurants, err := h.organizationSvc.GetRestaurants(r.Context(), organizationID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, restaurants)
+}
+
+type addOrganizationRestaurantRequest struct {
+	RestaurantID string `json:"restaurant_id" validate:"required,uuid"`
+}
+
+func (h *OrganizationHandler) AddRestaurant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request addOrganizationRestaurantRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	err = h.organizationSvc.AddRestaurant(ctx, organizationID, uuid.MustParse(request.RestaurantID), userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *OrganizationHandler) RemoveRestaurant(w http.ResponseWriter, r *http.Request) {
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := uuid.Parse(r.PathValue("restaurantID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	err = h.organizationSvc.RemoveRestaurant(r.Context(), organizationID, restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *OrganizationHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	members, err := h.organizationSvc.GetMembers(r.Context(), organizationID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, members)
+}
+
+type addOrganizationMemberRequest struct {
+	Email  string `json:"email" validate:"notblank,email"`
+	RoleID int    `json:"role_id" validate:"oneof=1 2"`
+}
+
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request addOrganizationMemberRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	err = h.organizationSvc.AddMember(ctx, organizationID, strings.TrimSpace(request.Email), enum.RoleID(request.RoleID))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	if !isOrganizationOwner(r) {
+		response.HandleError(w, response.ErrForbidden)
+		return
+	}
+
+	organizationID, err := keys.GetOrganizationIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	err = h.organizationSvc.RemoveMember(r.Context(), organizationID, userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
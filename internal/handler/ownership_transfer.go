@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type OwnershipTransferHandler struct {
+	ownershipTransferSvc service.OwnershipTransferService
+}
+
+func NewOwnershipTransferHandler(ownershipTransferSvc service.OwnershipTransferService) *OwnershipTransferHandler {
+	return &OwnershipTransferHandler{
+		ownershipTransferSvc: ownershipTransferSvc,
+	}
+}
+
+type nominateOwnerRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+func (h *OwnershipTransferHandler) Nominate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request nominateOwnerRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	transfer, err := h.ownershipTransferSvc.Nominate(ctx, restaurantID, userID, uuid.MustParse(request.UserID))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, transfer)
+}
+
+func (h *OwnershipTransferHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	transfer, err := h.ownershipTransferSvc.GetPendingByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, transfer)
+}
+
+func (h *OwnershipTransferHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	err = h.ownershipTransferSvc.Cancel(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *OwnershipTransferHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	spt := r.URL.Query().Get("token")
+	if spt == "" {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	transfer, err := h.ownershipTransferSvc.Confirm(r.Context(), spt)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, transfer)
+}
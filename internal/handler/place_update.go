@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type PlaceUpdateHandler struct {
+	placeSyncSvc service.PlaceSyncService
+}
+
+func NewPlaceUpdateHandler(placeSyncSvc service.PlaceSyncService) *PlaceUpdateHandler {
+	return &PlaceUpdateHandler{
+		placeSyncSvc: placeSyncSvc,
+	}
+}
+
+func (h *PlaceUpdateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	updates, err := h.placeSyncSvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, updates)
+}
+
+func (h *PlaceUpdateHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.placeSyncSvc.Accept)
+}
+
+func (h *PlaceUpdateHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.placeSyncSvc.Dismiss)
+}
+
+// resolve runs the resolution for the user, or with no user for API key requests which are audited under the key.
+func (h *PlaceUpdateHandler) resolve(w http.ResponseWriter, r *http.Request, resolveFn func(ctx context.Context, restaurantID uuid.UUID, id int, userID *uuid.UUID) (*dto.PlaceUpdate, error)) {
+	ctx := r.Context()
+
+	var userID *uuid.UUID
+	if id, err := keys.GetUserIDFromContext(ctx); err == nil {
+		userID = &id
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	updateID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	update, err := resolveFn(ctx, restaurantID, updateID, userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, update)
+}
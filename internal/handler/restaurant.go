@@ -2,8 +2,11 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/dto"
 	"github.com/memsbdm/restaurant-api/internal/response"
 	"github.com/memsbdm/restaurant-api/internal/service"
 	"github.com/memsbdm/restaurant-api/internal/validation"
@@ -53,3 +56,123 @@ func (h *RestaurantHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	response.HandleSuccess(w, http.StatusCreated, restaurant)
 }
+
+type updateRestaurantRequest struct {
+	Name    *string `json:"name" validate:"omitempty,notblank,max=50"`
+	Address *string `json:"address" validate:"omitempty,notblank,max=255"`
+	Phone   *string `json:"phone" validate:"omitempty,max=30"`
+}
+
+func (h *RestaurantHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request updateRestaurantRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	restaurant, err := h.restaurantSvc.Update(ctx, restaurantID, &dto.UpdateRestaurant{
+		Name:    request.Name,
+		Address: request.Address,
+		Phone:   request.Phone,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, restaurant)
+}
+
+func (h *RestaurantHandler) GetPublic(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	restaurant, err := h.restaurantSvc.GetPublicByID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, restaurant)
+}
+
+const (
+	defaultNearbyRadius = 5000
+	defaultNearbyLimit  = 20
+)
+
+type nearbyRestaurantsRequest struct {
+	Lat    *float64 `validate:"required,latitude"`
+	Lng    *float64 `validate:"required,longitude"`
+	Radius float64  `validate:"gt=0,lte=50000"`
+	Page   int      `validate:"min=1,max=1000"`
+	Limit  int      `validate:"min=1,max=100"`
+}
+
+func (h *RestaurantHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := nearbyRestaurantsRequest{
+		Radius: defaultNearbyRadius,
+		Page:   1,
+		Limit:  defaultNearbyLimit,
+	}
+
+	for key, dst := range map[string]**float64{"lat": &request.Lat, "lng": &request.Lng} {
+		if value := query.Get(key); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				response.HandleError(w, response.ErrBadRequest)
+				return
+			}
+			*dst = &f
+		}
+	}
+	if value := query.Get("radius"); value != "" {
+		radius, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			response.HandleError(w, response.ErrBadRequest)
+			return
+		}
+		request.Radius = radius
+	}
+	for key, dst := range map[string]*int{"page": &request.Page, "limit": &request.Limit} {
+		if value := query.Get(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				response.HandleError(w, response.ErrBadRequest)
+				return
+			}
+			*dst = n
+		}
+	}
+
+	if errs := validation.ValidateStruct(&request); len(errs) != 0 {
+		response.HandleValidationError(w, errs, nil)
+		return
+	}
+
+	restaurants, err := h.restaurantSvc.GetNearby(r.Context(), dto.NearbyRestaurantsFilter{
+		Lat:     *request.Lat,
+		Lng:     *request.Lng,
+		Radius:  request.Radius,
+		Page:    request.Page,
+		PerPage: request.Limit,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, restaurants)
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type RestaurantInviteHandler struct {
+	restaurantInviteSvc service.RestaurantInviteService
+}
+
+func NewRestaurantInviteHandler(restaurantInviteSvc service.RestaurantInviteService) *RestaurantInviteHandler {
+	return &RestaurantInviteHandler{
+		restaurantInviteSvc: restaurantInviteSvc,
+	}
+}
+
+type createRestaurantInviteRequest struct {
+	Email  string `json:"email" validate:"notblank,email,max=255"`
+	RoleID int    `json:"role_id" validate:"gt=0,lte=2147483647"`
+}
+
+func (h *RestaurantInviteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request createRestaurantInviteRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	invite, err := h.restaurantInviteSvc.Create(ctx, &dto.CreateRestaurantInvite{
+		RestaurantID:    restaurantID,
+		InvitedByUserID: userID,
+		Email:           strings.TrimSpace(request.Email),
+		RoleID:          request.RoleID,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, invite)
+}
+
+func (h *RestaurantInviteHandler) GetPending(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	invites, err := h.restaurantInviteSvc.GetPendingByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, invites)
+}
+
+func (h *RestaurantInviteHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	inviteID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.restaurantInviteSvc.Resend(r.Context(), restaurantID, inviteID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *RestaurantInviteHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	inviteID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	invite, err := h.restaurantInviteSvc.Cancel(ctx, restaurantID, inviteID, userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, invite)
+}
+
+// AcceptFromLink accepts the invite from the email link, it only works for invitees who already have an account.
+func (h *RestaurantInviteHandler) AcceptFromLink(w http.ResponseWriter, r *http.Request) {
+	spt := r.URL.Query().Get("token")
+	if spt == "" {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	invite, err := h.restaurantInviteSvc.Accept(r.Context(), spt, nil)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, invite)
+}
+
+type acceptRestaurantInviteRequest struct {
+	Token    string  `json:"token" validate:"notblank"`
+	Name     *string `json:"name" validate:"required_with=Password,omitempty,notblank,max=50"`
+	Password *string `json:"password" validate:"required_with=Name,omitempty,min=8"`
+}
+
+func (h *RestaurantInviteHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	var request acceptRestaurantInviteRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	var registration *dto.CreateUser
+	if request.Name != nil && request.Password != nil {
+		registration = &dto.CreateUser{
+			Name:     strings.TrimSpace(*request.Name),
+			Password: *request.Password,
+		}
+	}
+
+	invite, err := h.restaurantInviteSvc.Accept(r.Context(), strings.TrimSpace(request.Token), registration)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, invite)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type RestaurantMemberHandler struct {
+	cfg               *config.App
+	restaurantUserSvc service.RestaurantUserService
+}
+
+func NewRestaurantMemberHandler(cfg *config.App, restaurantUserSvc service.RestaurantUserService) *RestaurantMemberHandler {
+	return &RestaurantMemberHandler{
+		cfg:               cfg,
+		restaurantUserSvc: restaurantUserSvc,
+	}
+}
+
+func (h *RestaurantMemberHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	members, err := h.restaurantUserSvc.GetMembers(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, members)
+}
+
+type updateMemberRoleRequest struct {
+	RoleID int `json:"role_id" validate:"gt=0,lte=2147483647"`
+}
+
+func (h *RestaurantMemberHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	actorID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request updateMemberRoleRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	err = h.restaurantUserSvc.UpdateMemberRole(ctx, restaurantID, actorID, userID, enum.RoleID(request.RoleID))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *RestaurantMemberHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	actorID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.restaurantUserSvc.RemoveMember(ctx, restaurantID, actorID, userID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *RestaurantMemberHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	if err := h.restaurantUserSvc.Leave(ctx, restaurantID, userID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	if IsMobileRequest(r) {
+		w.Header().Del(keys.ActiveRestaurantHeaderName)
+	} else {
+		ClearActiveRestaurantCookie(w, h.cfg.Env)
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type RestaurantSettingsHandler struct {
+	restaurantSettingsSvc service.RestaurantSettingsService
+}
+
+func NewRestaurantSettingsHandler(restaurantSettingsSvc service.RestaurantSettingsService) *RestaurantSettingsHandler {
+	return &RestaurantSettingsHandler{
+		restaurantSettingsSvc: restaurantSettingsSvc,
+	}
+}
+
+func (h *RestaurantSettingsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	settings, err := h.restaurantSettingsSvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, settings)
+}
+
+type updateRestaurantSettingsRequest struct {
+	Timezone         *string  `json:"timezone" validate:"omitempty,timezone"`
+	Currency         *string  `json:"currency" validate:"omitempty,iso4217"`
+	Locale           *string  `json:"locale" validate:"omitempty,bcp47_language_tag,max=10"`
+	PricesIncludeTax *bool    `json:"prices_include_tax"`
+	DefaultTaxRate   *float64 `json:"default_tax_rate" validate:"omitempty,gte=0,lte=100"`
+}
+
+func (h *RestaurantSettingsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request updateRestaurantSettingsRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	settings, err := h.restaurantSettingsSvc.Update(ctx, restaurantID, &dto.UpdateRestaurantSettings{
+		Timezone:         request.Timezone,
+		Currency:         request.Currency,
+		Locale:           request.Locale,
+		PricesIncludeTax: request.PricesIncludeTax,
+		DefaultTaxRate:   request.DefaultTaxRate,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, settings)
+}
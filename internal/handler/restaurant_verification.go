@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type RestaurantVerificationHandler struct {
+	restaurantVerificationSvc service.RestaurantVerificationService
+}
+
+func NewRestaurantVerificationHandler(restaurantVerificationSvc service.RestaurantVerificationService) *RestaurantVerificationHandler {
+	return &RestaurantVerificationHandler{
+		restaurantVerificationSvc: restaurantVerificationSvc,
+	}
+}
+
+type requestRestaurantVerificationRequest struct {
+	Method        string  `json:"method" validate:"oneof=EMAIL DOCUMENT"`
+	BusinessEmail *string `json:"business_email" validate:"required_if=Method EMAIL,omitempty,email,max=255"`
+	DocumentURL   *string `json:"document_url" validate:"required_if=Method DOCUMENT,omitempty,url,max=255"`
+}
+
+func (h *RestaurantVerificationHandler) Request(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request requestRestaurantVerificationRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	verification := &dto.CreateRestaurantVerification{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Method:       enum.VerificationMethod(request.Method),
+	}
+	switch verification.Method {
+	case enum.VerificationMethodEmail:
+		businessEmail := strings.TrimSpace(*request.BusinessEmail)
+		verification.BusinessEmail = &businessEmail
+	case enum.VerificationMethodDocument:
+		verification.DocumentURL = request.DocumentURL
+	}
+
+	createdVerification, err := h.restaurantVerificationSvc.Request(ctx, verification)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, createdVerification)
+}
+
+func (h *RestaurantVerificationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	verification, err := h.restaurantVerificationSvc.GetLatestByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, verification)
+}
+
+type confirmRestaurantVerificationRequest struct {
+	Code string `json:"code" validate:"notblank"`
+}
+
+func (h *RestaurantVerificationHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request confirmRestaurantVerificationRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	verification, err := h.restaurantVerificationSvc.ConfirmCode(r.Context(), restaurantID, strings.TrimSpace(request.Code))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, verification)
+}
+
+func (h *RestaurantVerificationHandler) GetSubmitted(w http.ResponseWriter, r *http.Request) {
+	verifications, err := h.restaurantVerificationSvc.GetSubmitted(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, verifications)
+}
+
+func (h *RestaurantVerificationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	adminID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	verificationID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	verification, err := h.restaurantVerificationSvc.Approve(r.Context(), verificationID, adminID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, verification)
+}
+
+type rejectRestaurantVerificationRequest struct {
+	Reason *string `json:"reason" validate:"omitempty,max=500"`
+}
+
+func (h *RestaurantVerificationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	adminID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	verificationID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request rejectRestaurantVerificationRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	verification, err := h.restaurantVerificationSvc.Reject(r.Context(), verificationID, adminID, request.Reason)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, verification)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type RoleHandler struct {
+	permissionSvc service.PermissionService
+	roleSvc       service.RoleService
+}
+
+func NewRoleHandler(roleSvc service.RoleService, permissionSvc service.PermissionService) *RoleHandler {
+	return &RoleHandler{
+		permissionSvc: permissionSvc,
+		roleSvc:       roleSvc,
+	}
+}
+
+func (h *RoleHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	roles, err := h.roleSvc.GetByRestaurantID(r.Context(), restaurantID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, roles)
+}
+
+func (h *RoleHandler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	response.HandleSuccess(w, http.StatusOK, h.permissionSvc.GetCatalogue())
+}
+
+type saveRoleRequest struct {
+	Name        string   `json:"name" validate:"notblank,max=50"`
+	Permissions []string `json:"permissions" validate:"required"`
+}
+
+// newSaveRole trims the request values, unknown permissions are rejected by the service.
+func newSaveRole(request *saveRoleRequest) *dto.SaveRole {
+	permissions := make([]enum.Permission, len(request.Permissions))
+	for i, permission := range request.Permissions {
+		permissions[i] = enum.Permission(strings.TrimSpace(permission))
+	}
+
+	return &dto.SaveRole{
+		Name:        strings.TrimSpace(request.Name),
+		Permissions: permissions,
+	}
+}
+
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request saveRoleRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	role, err := h.roleSvc.Create(ctx, restaurantID, userID, newSaveRole(&request))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, role)
+}
+
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	restaurantID, err := keys.GetRestaurantIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	roleID, err := parseRoleID(r)
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	var request saveRoleRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	role, err := h.roleSvc.Update(ctx, restaurantID, userID, roleID, newSaveRole(&request))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, role)
+}
+
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	restaurantID, err := keys.GetRestaurantIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	roleID, err := parseRoleID(r)
+	if err != nil {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.roleSvc.Delete(r.Context(), restaurantID, roleID); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+// parseRoleID reads the role ID of the path, role IDs are stored as INTEGER.
+func parseRoleID(r *http.Request) (int, error) {
+	roleID, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+	return int(roleID), err
+}
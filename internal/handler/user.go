@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/internal/validation"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type UserHandler struct {
+	cfg          *config.App
+	authSvc      service.AuthService
+	sessionSvc   service.SessionService
+	twoFactorSvc service.TwoFactorService
+	userSvc      service.UserService
+}
+
+func NewUserHandler(cfg *config.App, authSvc service.AuthService, sessionSvc service.SessionService, twoFactorSvc service.TwoFactorService, userSvc service.UserService) *UserHandler {
+	return &UserHandler{
+		cfg:          cfg,
+		authSvc:      authSvc,
+		sessionSvc:   sessionSvc,
+		twoFactorSvc: twoFactorSvc,
+		userSvc:      userSvc,
+	}
+}
+
+func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	profile, err := h.userSvc.GetProfile(r.Context(), userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, profile)
+}
+
+type updateMeRequest struct {
+	Name      *string `json:"name" validate:"omitempty,notblank,max=50"`
+	AvatarURL *string `json:"avatar_url" validate:"omitempty,max=255,eq=|url"`
+}
+
+// UpdateMe only changes the fields sent, an empty avatar URL removes the avatar.
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request updateMeRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		request.Name = &name
+	}
+
+	user, err := h.userSvc.Update(ctx, userID, &dto.UpdateUser{
+		Name:      request.Name,
+		AvatarURL: request.AvatarURL,
+	})
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, user)
+}
+
+type deleteMeRequest struct {
+	Password string `json:"password" validate:"notblank"`
+}
+
+// DeleteMe schedules the deletion of the account and signs the user out everywhere, logging in again
+// before the deletion date cancels it.
+func (h *UserHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request deleteMeRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	deletion, err := h.authSvc.DeleteAccount(ctx, userID, request.Password)
+	if err != nil {
+		var blockedErr *service.AccountDeletionBlockedError
+		if errors.As(err, &blockedErr) {
+			response.HandleErrorDetails(w, service.ErrAccountDeletionBlocked, blockedErr.Restaurants)
+			return
+		}
+		response.HandleError(w, err)
+		return
+	}
+
+	if !IsMobileRequest(r) {
+		clearAuthCookie(w, h.cfg.Env)
+		clearCSRFCookie(w, h.cfg.Env)
+	}
+
+	response.HandleSuccess(w, http.StatusAccepted, deletion)
+}
+
+type changeEmailRequest struct {
+	Email string `json:"email" validate:"notblank,email"`
+}
+
+// RequestEmailChange keeps the current email until the new one is confirmed.
+func (h *UserHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request changeEmailRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	email := strings.TrimSpace(request.Email)
+	if err := h.userSvc.RequestEmailChange(ctx, userID, email); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusAccepted, nil)
+}
+
+func (h *UserHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	spt := r.URL.Query().Get("token")
+	if spt == "" {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	updatedUser, err := h.userSvc.ConfirmEmailChange(r.Context(), spt)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, updatedUser)
+}
+
+func (h *UserHandler) CancelEmailChange(w http.ResponseWriter, r *http.Request) {
+	spt := r.URL.Query().Get("token")
+	if spt == "" {
+		response.HandleError(w, response.ErrBadRequest)
+		return
+	}
+
+	if err := h.userSvc.CancelEmailChange(r.Context(), spt); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"notblank"`
+	NewPassword     string `json:"new_password" validate:"notblank,min=8"`
+}
+
+// ChangePassword keeps the current session alive, every other session of the user is signed out.
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	oat, err := keys.GetValueFromContext(ctx, keys.AuthOATContextKey)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request changePasswordRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	err = h.authSvc.ChangePassword(ctx, userID, oat, request.CurrentPassword, request.NewPassword)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *UserHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := keys.GetUserIDFromContext(ctx)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	oat, err := keys.GetValueFromContext(ctx, keys.AuthOATContextKey)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	sessions, err := h.sessionSvc.GetByUserID(ctx, userID, oat)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, sessions)
+}
+
+func (h *UserHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	if err := h.sessionSvc.Revoke(r.Context(), userID, r.PathValue("id")); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
+
+func (h *UserHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	enrollment, err := h.twoFactorSvc.Enroll(r.Context(), userID)
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusCreated, enrollment)
+}
+
+type confirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"notblank"`
+}
+
+// ConfirmTwoFactor enables two-factor authentication, the response holds the only copy of the recovery codes.
+func (h *UserHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request confirmTwoFactorRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	recoveryCodes, err := h.twoFactorSvc.Confirm(r.Context(), userID, strings.TrimSpace(request.Code))
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusOK, recoveryCodes)
+}
+
+type disableTwoFactorRequest struct {
+	Password string `json:"password" validate:"notblank"`
+}
+
+func (h *UserHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := keys.GetUserIDFromContext(r.Context())
+	if err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	var request disableTwoFactorRequest
+	if errs, err := validation.ValidateRequest(w, r, &request); err != nil || len(errs) != 0 {
+		response.HandleValidationError(w, errs, err)
+		return
+	}
+
+	if err := h.twoFactorSvc.Disable(r.Context(), userID, request.Password); err != nil {
+		response.HandleError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn right away and then at each interval until ctx is done, failures are logged.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("job %s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("job %s stopped", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+// AdminMiddleware restricts a route to platform admins, it must run after AuthMiddleware.
+func AdminMiddleware(userSvc service.UserService) Middle {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := keys.GetUserIDFromContext(r.Context())
+			if err != nil {
+				response.HandleError(w, response.ErrUnauthorized)
+				return
+			}
+
+			user, err := userSvc.GetByID(r.Context(), userID)
+			if err != nil {
+				response.HandleError(w, err)
+				return
+			}
+
+			if !user.IsAdmin {
+				response.HandleError(w, response.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -12,9 +13,22 @@ import (
 	"github.com/memsbdm/restaurant-api/pkg/security"
 )
 
-func AuthMiddleware(appEnv string, tokenSvc service.TokenService, authSvc service.AuthService) Middle {
+// AuthMiddleware authenticates the user from the OAT, or the restaurant integration from the API key header.
+// API key requests carry no user, their restaurant and scopes are put into context instead.
+func AuthMiddleware(appEnv string, tokenSvc service.TokenService, authSvc service.AuthService, apiKeySvc service.APIKeyService, restaurantSvc service.RestaurantService) Middle {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get(keys.APIKeyHeaderName); key != "" {
+				ctx, err := authenticateAPIKey(r.Context(), key, apiKeySvc, restaurantSvc)
+				if err != nil {
+					response.HandleError(w, err)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			oat, err := extractAuthOATFromRequest(r)
 			if err != nil {
 				response.HandleError(w, err)
@@ -27,8 +41,14 @@ func AuthMiddleware(appEnv string, tokenSvc service.TokenService, authSvc servic
 				return
 			}
 
-			// Reset cookie expiration time
+			// Reset cookies expiration time
 			handler.SetAuthCookie(w, oat, appEnv)
+			if !handler.IsMobileRequest(r) {
+				if err := handler.RefreshCSRFCookie(w, r, appEnv); err != nil {
+					response.HandleError(w, err)
+					return
+				}
+			}
 			// Enrich context with user ID and OAT
 			ctx := context.WithValue(r.Context(), keys.UserIDContextKey, userID)
 			decodedOAT, _ := security.DecodeTokenURLSafe(oat)
@@ -36,8 +56,11 @@ func AuthMiddleware(appEnv string, tokenSvc service.TokenService, authSvc servic
 			ctx = context.WithValue(ctx, keys.AuthOATContextKey, oat)
 
 			// Reset the OAT cache TTL
-			err = authSvc.ResetAuthOATCacheTTL(r.Context(), oat, userID)
+			err = authSvc.ResetAuthOATCacheTTL(r.Context(), oat, userID, handler.NewSessionClient(r))
 			if err != nil {
+				if errors.Is(err, service.ErrInvalidToken) {
+					err = response.ErrUnauthorized
+				}
 				response.HandleError(w, err)
 				return
 			}
@@ -47,6 +70,29 @@ func AuthMiddleware(appEnv string, tokenSvc service.TokenService, authSvc servic
 	}
 }
 
+func authenticateAPIKey(ctx context.Context, key string, apiKeySvc service.APIKeyService, restaurantSvc service.RestaurantService) (context.Context, error) {
+	apiKey, err := apiKeySvc.Authenticate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurant, err := restaurantSvc.GetByID(ctx, apiKey.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	ctx = context.WithValue(ctx, keys.RestaurantIDContextKey, restaurant.ID)
+	ctx = context.WithValue(ctx, keys.RestaurantContextKey, restaurant)
+	ctx = context.WithValue(ctx, keys.APIKeyIDContextKey, apiKey.ID)
+	ctx = context.WithValue(ctx, keys.APIKeyScopesContextKey, scopes)
+	return ctx, nil
+}
+
 func GuestMiddleware(tokenSvc service.TokenService) Middle {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+// ClientIPMiddleware resolves the client IP used for sessions and login attempt limits.
+// X-Forwarded-For is set by the client, so it is only read when the peer is a trusted proxy, from the right,
+// and the first hop that was not added by a trusted proxy is the client.
+func ClientIPMiddleware(trustedProxies []netip.Prefix) Middle {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := remoteIP(r)
+			if isTrustedProxy(ip, trustedProxies) {
+				hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+				for i := len(hops) - 1; i >= 0; i-- {
+					hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+					if err != nil {
+						break
+					}
+					ip = hop.Unmap()
+					if !isTrustedProxy(ip, trustedProxies) {
+						break
+					}
+				}
+			}
+
+			if !ip.IsValid() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), keys.ClientIPContextKey, ip.String())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func remoteIP(r *http.Request) netip.Addr {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return ip.Unmap()
+}
+
+func isTrustedProxy(ip netip.Addr, trustedProxies []netip.Prefix) bool {
+	if !ip.IsValid() {
+		return false
+	}
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/memsbdm/restaurant-api/internal/handler"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+// CSRFMiddleware requires unsafe requests authenticated by the session cookie to echo the CSRF cookie
+// in the X-CSRF-Token header. Mobile, bearer token and API key requests are not sent automatically
+// by browsers and are exempt, as are requests without a session.
+func CSRFMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isSafeMethod(r.Method) || isCSRFExempt(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, err := r.Cookie(keys.AuthOATCookieName); err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cookie, err := r.Cookie(keys.CSRFCookieName)
+		if err != nil || cookie.Value == "" {
+			response.HandleError(w, response.ErrInvalidCSRFToken)
+			return
+		}
+
+		token := r.Header.Get(keys.CSRFHeaderName)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cookie.Value)) != 1 {
+			response.HandleError(w, response.ErrInvalidCSRFToken)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func isCSRFExempt(r *http.Request) bool {
+	if handler.IsMobileRequest(r) || r.Header.Get(keys.APIKeyHeaderName) != "" {
+		return true
+	}
+	return strings.HasPrefix(r.Header.Get(keys.AuthorizationHeaderName), "Bearer ")
+}
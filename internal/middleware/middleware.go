@@ -4,24 +4,39 @@ import (
 	"net/http"
 
 	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
 	"github.com/memsbdm/restaurant-api/internal/service"
 )
 
 type Middleware struct {
-	Auth       MiddlewareFunc
-	Guest      MiddlewareFunc
-	Logging    Middle
-	Restaurant MiddlewareFunc
+	Admin          MiddlewareFunc
+	Auth           MiddlewareFunc
+	ClientIP       Middle
+	CSRF           Middle
+	Guest          MiddlewareFunc
+	Logging        Middle
+	Organization   MiddlewareFunc
+	Restaurant     MiddlewareFunc
+	RestaurantPath MiddlewareFunc
+	Require        func(permission enum.Permission) MiddlewareFunc
 }
 
 type MiddlewareFunc func(handler func(http.ResponseWriter, *http.Request)) http.Handler
 
 func New(cfg *config.Container, s *service.Services) *Middleware {
 	return &Middleware{
-		Auth:       newHandlerMiddleware(AuthMiddleware(cfg.App.Env, s.TokenService, s.AuthService)),
-		Guest:      newHandlerMiddleware(GuestMiddleware(s.TokenService)),
-		Logging:    LoggingMiddleware,
-		Restaurant: newHandlerMiddleware(RestaurantMiddleware(cfg.App.Env, s.RestaurantService, s.RestaurantUserService)),
+		Admin:          newHandlerMiddleware(AdminMiddleware(s.UserService)),
+		Auth:           newHandlerMiddleware(AuthMiddleware(cfg.App.Env, s.TokenService, s.AuthService, s.APIKeyService, s.RestaurantService)),
+		ClientIP:       ClientIPMiddleware(cfg.Server.TrustedProxies),
+		CSRF:           CSRFMiddleware,
+		Guest:          newHandlerMiddleware(GuestMiddleware(s.TokenService)),
+		Logging:        LoggingMiddleware,
+		Organization:   newHandlerMiddleware(OrganizationMiddleware(s.OrganizationService)),
+		Restaurant:     newHandlerMiddleware(RestaurantMiddleware(cfg.App.Env, s.RestaurantService, s.RestaurantUserService)),
+		RestaurantPath: newHandlerMiddleware(RestaurantPathMiddleware(s.RestaurantService, s.RestaurantUserService)),
+		Require: func(permission enum.Permission) MiddlewareFunc {
+			return newHandlerMiddleware(PermissionMiddleware(s.PermissionService, permission))
+		},
 	}
 }
 
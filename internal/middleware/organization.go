@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+// OrganizationMiddleware loads the organization named by the {organizationID} path value and the user role in it,
+// it must run after AuthMiddleware.
+func OrganizationMiddleware(organizationSvc service.OrganizationService) Middle {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			userID, err := keys.GetUserIDFromContext(ctx)
+			if err != nil {
+				response.HandleError(w, response.ErrUnauthorized)
+				return
+			}
+
+			organizationID, err := uuid.Parse(r.PathValue("organizationID"))
+			if err != nil {
+				response.HandleError(w, response.ErrBadRequest)
+				return
+			}
+
+			roleID, err := organizationSvc.GetUserRoleID(ctx, organizationID, userID)
+			if err != nil {
+				if errors.Is(err, service.ErrOrganizationMemberNotFound) {
+					response.HandleError(w, response.ErrForbidden)
+					return
+				}
+				response.HandleError(w, err)
+				return
+			}
+
+			ctx = context.WithValue(ctx, keys.OrganizationIDContextKey, organizationID)
+			ctx = context.WithValue(ctx, keys.OrganizationRoleIDContextKey, int32(roleID))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
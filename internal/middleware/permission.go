@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/response"
+	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+// PermissionMiddleware restricts a route to members whose role grants the permission, or to API keys
+// holding it as a scope. It must run after RestaurantMiddleware or RestaurantPathMiddleware.
+func PermissionMiddleware(permissionSvc service.PermissionService, permission enum.Permission) Middle {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if scopes, err := keys.GetAPIKeyScopesFromContext(r.Context()); err == nil {
+				if !slices.Contains(scopes, string(permission)) {
+					response.HandleError(w, response.ErrForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			roleID, err := keys.GetUserRoleIDFromContext(r.Context())
+			if err != nil {
+				response.HandleError(w, response.ErrForbidden)
+				return
+			}
+
+			allowed, err := permissionSvc.HasPermission(r.Context(), roleID, permission)
+			if err != nil {
+				response.HandleError(w, err)
+				return
+			}
+
+			if !allowed {
+				response.HandleError(w, response.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -17,6 +17,13 @@ func RestaurantMiddleware(appEnv string, restaurantSvc service.RestaurantService
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
+
+			// API keys are bound to a restaurant, AuthMiddleware already put it into context
+			if _, err := keys.GetAPIKeyScopesFromContext(ctx); err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			userID, err := keys.GetUserIDFromContext(ctx)
 			if err != nil {
 				response.HandleError(w, response.ErrUnauthorized)
@@ -45,6 +52,10 @@ func RestaurantMiddleware(appEnv string, restaurantSvc service.RestaurantService
 			restaurantUser, err := restaurantUserSvc.GetAnyRestaurantUserLinkByUserID(ctx, userID)
 			if err != nil {
 				if errors.Is(err, service.ErrRestaurantOrUserNotFound) {
+					// Drop a stale active restaurant, e.g. after the user was removed from its team
+					if !handler.IsMobileRequest(r) {
+						handler.ClearActiveRestaurantCookie(w, appEnv)
+					}
 					response.HandleError(w, service.ErrNoRestaurantFoundForUser)
 					return
 				}
@@ -67,6 +78,56 @@ func RestaurantMiddleware(appEnv string, restaurantSvc service.RestaurantService
 	}
 }
 
+// RestaurantPathMiddleware loads the restaurant named by the {restaurantID} path value, unlike
+// RestaurantMiddleware it never falls back to another restaurant nor sets the active restaurant.
+func RestaurantPathMiddleware(restaurantSvc service.RestaurantService, restaurantUserSvc service.RestaurantUserService) Middle {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			restaurantID, err := uuid.Parse(r.PathValue("restaurantID"))
+			if err != nil {
+				response.HandleError(w, response.ErrBadRequest)
+				return
+			}
+
+			if _, err := keys.GetAPIKeyScopesFromContext(ctx); err == nil {
+				keyRestaurantID, _ := keys.GetRestaurantIDFromContext(ctx)
+				if keyRestaurantID != restaurantID {
+					response.HandleError(w, response.ErrForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, err := keys.GetUserIDFromContext(ctx)
+			if err != nil {
+				response.HandleError(w, response.ErrUnauthorized)
+				return
+			}
+
+			roleID, err := restaurantUserSvc.GetRestaurantUserRoleID(ctx, restaurantID, userID)
+			if err != nil {
+				if errors.Is(err, service.ErrRestaurantOrUserNotFound) {
+					response.HandleError(w, response.ErrForbidden)
+					return
+				}
+				response.HandleError(w, err)
+				return
+			}
+
+			restaurant, err := restaurantSvc.GetByID(ctx, restaurantID)
+			if err != nil {
+				response.HandleError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(enrichContextWithRestaurantInfos(ctx, restaurant, roleID)))
+		})
+	}
+}
+
 func extractActiveRestaurantIDFromRequest(r *http.Request) (uuid.UUID, error) {
 	var restaurantID uuid.UUID
 	var err error
@@ -111,7 +172,7 @@ func getActiveRestaurantIDFromHeader(r *http.Request) (uuid.UUID, error) {
 func enrichContextWithRestaurantInfos(ctx context.Context, restaurant *dto.Restaurant, userRoleID int) context.Context {
 	ctx = context.WithValue(ctx, keys.RestaurantIDContextKey, restaurant.ID)
 	ctx = context.WithValue(ctx, keys.RestaurantContextKey, restaurant)
-	ctx = context.WithValue(ctx, keys.UserRoleIDContextKey, userRoleID)
+	ctx = context.WithValue(ctx, keys.UserRoleIDContextKey, int32(userRoleID))
 	return ctx
 }
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/memsbdm/restaurant-api/internal/service"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
 )
 
 var (
@@ -17,6 +18,7 @@ var (
 
 	// Middleware
 	ErrNoRestaurantFoundForUser = errors.New("no restaurant found for user")
+	ErrInvalidCSRFToken         = errors.New("missing or invalid CSRF token")
 )
 
 var ErrToHttpStatusCode = map[error]int{
@@ -27,8 +29,12 @@ var ErrToHttpStatusCode = map[error]int{
 	ErrInternal:           http.StatusInternalServerError,
 	ErrServiceUnavailable: http.StatusServiceUnavailable,
 
+	// Middleware
+	ErrInvalidCSRFToken: http.StatusForbidden,
+
 	// Conflict
 	service.ErrEmailConflict:          http.StatusConflict,
+	service.ErrSameEmail:              http.StatusBadRequest,
 	service.ErrEmailAlreadyVerified:   http.StatusForbidden,
 	service.ErrRestaurantAlreadyTaken: http.StatusConflict,
 
@@ -37,9 +43,101 @@ var ErrToHttpStatusCode = map[error]int{
 
 	// Auth
 	service.ErrInvalidCredentials: http.StatusUnauthorized,
+	service.ErrIncorrectPassword:  http.StatusBadRequest,
+	service.ErrLoginLocked:        http.StatusTooManyRequests,
+	service.ErrSessionNotFound:    http.StatusNotFound,
+	service.ErrRefreshTokenReused: http.StatusUnauthorized,
+	keys.ErrUserIDNotFound:        http.StatusUnauthorized,
+
+	// API keys
+	service.ErrInvalidAPIKey:      http.StatusUnauthorized,
+	service.ErrAPIKeyNotFound:     http.StatusNotFound,
+	service.ErrInvalidAPIKeyScope: http.StatusBadRequest,
+	service.ErrAPIKeyExpiryInPast: http.StatusBadRequest,
+
+	// Account deletion
+	service.ErrAccountDeletionBlocked: http.StatusConflict,
+
+	// Two-factor authentication
+	service.ErrTwoFactorAlreadyEnabled: http.StatusConflict,
+	service.ErrTwoFactorNotEnrolled:    http.StatusNotFound,
+	service.ErrTwoFactorNotEnabled:     http.StatusConflict,
+	service.ErrInvalidTwoFactorCode:    http.StatusUnauthorized,
+
+	// OIDC
+	service.ErrOIDCDisabled:         http.StatusNotFound,
+	service.ErrOIDCUnavailable:      http.StatusServiceUnavailable,
+	service.ErrOIDCInvalidState:     http.StatusBadRequest,
+	service.ErrOIDCInvalidIDToken:   http.StatusUnauthorized,
+	service.ErrOIDCEmailNotVerified: http.StatusForbidden,
 
 	// Restaurant
 	service.ErrNoRestaurantFoundForUser: http.StatusForbidden,
+	service.ErrRestaurantNotFound:       http.StatusNotFound,
+
+	// Restaurant verification
+	service.ErrRestaurantAlreadyVerified:          http.StatusConflict,
+	service.ErrRestaurantVerificationInProgress:   http.StatusConflict,
+	service.ErrRestaurantVerificationNotFound:     http.StatusNotFound,
+	service.ErrRestaurantVerificationNotPending:   http.StatusConflict,
+	service.ErrRestaurantVerificationNotSubmitted: http.StatusConflict,
+	service.ErrInvalidVerificationCode:            http.StatusBadRequest,
+	service.ErrVerificationCodeAttemptsExceeded:   http.StatusTooManyRequests,
+	service.ErrVerificationCodeExpired:            http.StatusBadRequest,
+
+	// Ownership transfer
+	service.ErrOwnershipTransferInProgress: http.StatusConflict,
+	service.ErrOwnershipTransferNotFound:   http.StatusNotFound,
+	service.ErrOwnershipTransferToSelf:     http.StatusBadRequest,
+	service.ErrNomineeNotMember:            http.StatusBadRequest,
+	service.ErrNominatorNotOwner:           http.StatusForbidden,
+
+	// Restaurant members
+	service.ErrRestaurantMemberNotFound: http.StatusNotFound,
+	service.ErrRestaurantLastOwner:      http.StatusConflict,
+	service.ErrOwnerRoleRequired:        http.StatusForbidden,
+
+	// Restaurant invites
+	service.ErrRestaurantInviteNotFound:    http.StatusNotFound,
+	service.ErrRestaurantInviteExists:      http.StatusConflict,
+	service.ErrInviteeAlreadyMember:        http.StatusConflict,
+	service.ErrInviteeRegistrationRequired: http.StatusBadRequest,
+
+	// Place updates
+	service.ErrPlaceUpdateNotFound:     http.StatusNotFound,
+	service.ErrPlaceUpdateNotSuggested: http.StatusConflict,
+
+	// Opening hours
+	service.ErrInvalidTimezone:              http.StatusBadRequest,
+	service.ErrOpeningHourExceptionNotFound: http.StatusNotFound,
+
+	// Restaurant settings
+	service.ErrRestaurantSettingsNotFound: http.StatusNotFound,
+
+	// Organizations
+	service.ErrOrganizationMemberNotFound:     http.StatusNotFound,
+	service.ErrOrganizationMemberExists:       http.StatusConflict,
+	service.ErrOrganizationLastOwner:          http.StatusConflict,
+	service.ErrRestaurantInOtherOrganization:  http.StatusConflict,
+	service.ErrRestaurantNotInOrganization:    http.StatusNotFound,
+	service.ErrRestaurantOwnershipRequired:    http.StatusForbidden,
+	service.ErrOrganizationInviteeNotVerified: http.StatusNotFound,
+
+	// Roles
+	service.ErrRoleNotFound:            http.StatusNotFound,
+	service.ErrRoleNameTaken:           http.StatusConflict,
+	service.ErrRoleReadOnly:            http.StatusForbidden,
+	service.ErrRoleInUse:               http.StatusConflict,
+	service.ErrRolePermissionsExceeded: http.StatusForbidden,
+	service.ErrRoleLimitReached:        http.StatusConflict,
+	service.ErrUnknownPermission:       http.StatusBadRequest,
+
+	// Menus
+	service.ErrMenuNotFound:              http.StatusNotFound,
+	service.ErrCategoryNotFound:          http.StatusNotFound,
+	service.ErrArticleNotFound:           http.StatusNotFound,
+	service.ErrArticleNotInherited:       http.StatusBadRequest,
+	service.ErrArticlePriceOverrideUnset: http.StatusNotFound,
 
 	// Mailer
 	service.ErrMailerUnavailable: http.StatusServiceUnavailable,
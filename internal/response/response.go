@@ -35,6 +35,26 @@ func HandleValidationError(w http.ResponseWriter, errs []validation.ValidationEr
 	json.NewEncoder(w).Encode(resp)
 }
 
+// HandleErrorDetails writes the error along with the details the client needs to resolve it.
+func HandleErrorDetails(w http.ResponseWriter, err error, details any) {
+	statusCode, ok := ErrToHttpStatusCode[err]
+	if !ok {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := struct {
+		Errors  []string `json:"errors"`
+		Details any      `json:"details"`
+	}{
+		[]string{err.Error()},
+		details,
+	}
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(resp)
+}
+
 func HandleError(w http.ResponseWriter, err error) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -3,6 +3,7 @@ package server
 import (
 	"net/http"
 
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
 	"github.com/memsbdm/restaurant-api/internal/handler"
 	"github.com/memsbdm/restaurant-api/internal/middleware"
 )
@@ -14,20 +15,104 @@ func registerRoutes(h *handler.Handlers, m *middleware.Middleware) http.Handler
 	r.Handle("POST /auth/register", m.Guest(h.AuthHandler.Register))
 	r.Handle("POST /auth/login", m.Guest(h.AuthHandler.Login))
 	r.Handle("DELETE /auth/logout", m.Auth(h.AuthHandler.Logout))
+	r.Handle("DELETE /auth/logout/all", m.Auth(h.AuthHandler.LogoutEverywhere))
+	r.HandleFunc("POST /auth/refresh", h.AuthHandler.Refresh)
+	r.Handle("GET /auth/google", m.Guest(h.AuthHandler.GoogleLogin))
+	r.Handle("GET /auth/google/callback", m.Guest(h.AuthHandler.GoogleCallback))
+	r.Handle("POST /auth/2fa/verify", m.Guest(h.AuthHandler.VerifyTwoFactor))
+	r.Handle("POST /auth/password/forgot", m.Guest(h.AuthHandler.ForgotPassword))
+	r.Handle("POST /auth/password/reset", m.Guest(h.AuthHandler.ResetPassword))
 
 	// Users
 	r.HandleFunc("GET /users/verify-email", h.VerifyEmailHandler.VerifyEmail)
 	r.Handle("POST /users/verify-email/resend", m.Auth(h.VerifyEmailHandler.ResendVerificationEmail))
+	r.Handle("GET /users/me", m.Auth(h.UserHandler.GetMe))
+	r.Handle("PATCH /users/me", m.Auth(h.UserHandler.UpdateMe))
+	r.Handle("DELETE /users/me", m.Auth(h.UserHandler.DeleteMe))
+	r.Handle("PUT /users/me/password", m.Auth(h.UserHandler.ChangePassword))
+	r.Handle("POST /users/me/email", m.Auth(h.UserHandler.RequestEmailChange))
+	r.HandleFunc("GET /users/email/confirm", h.UserHandler.ConfirmEmailChange)
+	r.HandleFunc("GET /users/email/cancel", h.UserHandler.CancelEmailChange)
+	r.Handle("GET /users/me/sessions", m.Auth(h.UserHandler.GetSessions))
+	r.Handle("DELETE /users/me/sessions/{id}", m.Auth(h.UserHandler.RevokeSession))
+	r.Handle("POST /users/me/2fa", m.Auth(h.UserHandler.EnrollTwoFactor))
+	r.Handle("POST /users/me/2fa/confirm", m.Auth(h.UserHandler.ConfirmTwoFactor))
+	r.Handle("DELETE /users/me/2fa", m.Auth(h.UserHandler.DisableTwoFactor))
 
 	// Restaurants
 	r.Handle("POST /restaurants", m.Auth(h.RestaurantHandler.Create))
+	r.Handle("PATCH /restaurants", middleware.Chain(h.RestaurantHandler.Update, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/opening-hours", middleware.Chain(h.OpeningHoursHandler.Get, m.Require(enum.PermissionRestaurantRead), m.Restaurant, m.Auth))
+	r.Handle("PUT /restaurants/opening-hours", middleware.Chain(h.OpeningHoursHandler.Update, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/opening-hours/exceptions", middleware.Chain(h.OpeningHoursHandler.CreateException, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/opening-hours/exceptions/{id}", middleware.Chain(h.OpeningHoursHandler.DeleteException, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+
+	r.Handle("GET /restaurants/verification", middleware.Chain(h.RestaurantVerificationHandler.Get, m.Require(enum.PermissionRestaurantRead), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/verification", middleware.Chain(h.RestaurantVerificationHandler.Request, m.Require(enum.PermissionRestaurantVerify), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/verification/confirm", middleware.Chain(h.RestaurantVerificationHandler.Confirm, m.Require(enum.PermissionRestaurantVerify), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/ownership-transfer", middleware.Chain(h.OwnershipTransferHandler.Get, m.Require(enum.PermissionRestaurantRead), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/ownership-transfer", middleware.Chain(h.OwnershipTransferHandler.Nominate, m.Require(enum.PermissionRestaurantTransfer), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/ownership-transfer", middleware.Chain(h.OwnershipTransferHandler.Cancel, m.Require(enum.PermissionRestaurantTransfer), m.Restaurant, m.Auth))
+	r.HandleFunc("GET /restaurants/ownership-transfer/confirm", h.OwnershipTransferHandler.Confirm)
+	r.Handle("GET /restaurants/invites", middleware.Chain(h.RestaurantInviteHandler.GetPending, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/invites", middleware.Chain(h.RestaurantInviteHandler.Create, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/invites/{id}/resend", middleware.Chain(h.RestaurantInviteHandler.Resend, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/invites/{id}", middleware.Chain(h.RestaurantInviteHandler.Cancel, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.HandleFunc("GET /restaurants/invites/accept", h.RestaurantInviteHandler.AcceptFromLink)
+	r.HandleFunc("POST /restaurants/invites/accept", h.RestaurantInviteHandler.Accept)
+	r.Handle("GET /restaurants/members", middleware.Chain(h.RestaurantMemberHandler.GetAll, m.Require(enum.PermissionTeamRead), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/members/{userID}", middleware.Chain(h.RestaurantMemberHandler.Remove, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.Handle("PATCH /restaurants/members/{userID}", middleware.Chain(h.RestaurantMemberHandler.UpdateRole, m.Require(enum.PermissionTeamManage), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/leave", middleware.Chain(h.RestaurantMemberHandler.Leave, m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/roles", middleware.Chain(h.RoleHandler.GetAll, m.Require(enum.PermissionTeamRead), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/roles/permissions", middleware.Chain(h.RoleHandler.GetPermissions, m.Require(enum.PermissionTeamRead), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/roles", middleware.Chain(h.RoleHandler.Create, m.Require(enum.PermissionRolesManage), m.Restaurant, m.Auth))
+	r.Handle("PUT /restaurants/roles/{id}", middleware.Chain(h.RoleHandler.Update, m.Require(enum.PermissionRolesManage), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/roles/{id}", middleware.Chain(h.RoleHandler.Delete, m.Require(enum.PermissionRolesManage), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/audit-log", middleware.Chain(h.AuditLogHandler.Get, m.Require(enum.PermissionAuditRead), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/api-keys", middleware.Chain(h.APIKeyHandler.GetAll, m.Require(enum.PermissionAPIKeysManage), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/api-keys", middleware.Chain(h.APIKeyHandler.Create, m.Require(enum.PermissionAPIKeysManage), m.Restaurant, m.Auth))
+	r.Handle("DELETE /restaurants/api-keys/{id}", middleware.Chain(h.APIKeyHandler.Revoke, m.Require(enum.PermissionAPIKeysManage), m.Restaurant, m.Auth))
+	r.Handle("GET /restaurants/place-updates", middleware.Chain(h.PlaceUpdateHandler.Get, m.Require(enum.PermissionRestaurantRead), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/place-updates/{id}/accept", middleware.Chain(h.PlaceUpdateHandler.Accept, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+	r.Handle("POST /restaurants/place-updates/{id}/dismiss", middleware.Chain(h.PlaceUpdateHandler.Dismiss, m.Require(enum.PermissionRestaurantWrite), m.Restaurant, m.Auth))
+
+	r.Handle("GET /restaurants/by-id/{restaurantID}/settings", middleware.Chain(h.RestaurantSettingsHandler.Get, m.Require(enum.PermissionRestaurantRead), m.RestaurantPath, m.Auth))
+	r.Handle("PATCH /restaurants/by-id/{restaurantID}/settings", middleware.Chain(h.RestaurantSettingsHandler.Update, m.Require(enum.PermissionRestaurantWrite), m.RestaurantPath, m.Auth))
+
+	// Public
+	r.HandleFunc("GET /public/restaurants/nearby", h.RestaurantHandler.GetNearby)
+	r.HandleFunc("GET /public/restaurants/{id}", h.RestaurantHandler.GetPublic)
 
 	// Menus
-	r.Handle("POST /menus", middleware.Chain(h.MenuHandler.Create, m.Restaurant, m.Auth))
+	r.Handle("GET /menus", middleware.Chain(h.MenuHandler.GetAll, m.Require(enum.PermissionMenuRead), m.Restaurant, m.Auth))
+	r.Handle("POST /menus", middleware.Chain(h.MenuHandler.Create, m.Require(enum.PermissionMenuWrite), m.Restaurant, m.Auth))
+	r.Handle("PUT /menus/articles/{id}/price-override", middleware.Chain(h.MenuHandler.SetPriceOverride, m.Require(enum.PermissionMenuWrite), m.Restaurant, m.Auth))
+	r.Handle("DELETE /menus/articles/{id}/price-override", middleware.Chain(h.MenuHandler.DeletePriceOverride, m.Require(enum.PermissionMenuWrite), m.Restaurant, m.Auth))
+	r.Handle("PUT /menus/articles/{id}/availability", middleware.Chain(h.MenuHandler.SetAvailability, m.Require(enum.PermissionMenuAvailability), m.Restaurant, m.Auth))
+
+	// Organizations
+	r.Handle("GET /organizations", m.Auth(h.OrganizationHandler.GetAll))
+	r.Handle("POST /organizations", m.Auth(h.OrganizationHandler.Create))
+	r.Handle("GET /organizations/{organizationID}/restaurants", middleware.Chain(h.OrganizationHandler.GetRestaurants, m.Organization, m.Auth))
+	r.Handle("POST /organizations/{organizationID}/restaurants", middleware.Chain(h.OrganizationHandler.AddRestaurant, m.Organization, m.Auth))
+	r.Handle("DELETE /organizations/{organizationID}/restaurants/{restaurantID}", middleware.Chain(h.OrganizationHandler.RemoveRestaurant, m.Organization, m.Auth))
+	r.Handle("GET /organizations/{organizationID}/members", middleware.Chain(h.OrganizationHandler.GetMembers, m.Organization, m.Auth))
+	r.Handle("POST /organizations/{organizationID}/members", middleware.Chain(h.OrganizationHandler.AddMember, m.Organization, m.Auth))
+	r.Handle("DELETE /organizations/{organizationID}/members/{userID}", middleware.Chain(h.OrganizationHandler.RemoveMember, m.Organization, m.Auth))
+	r.Handle("GET /organizations/{organizationID}/menus", middleware.Chain(h.MenuHandler.GetShared, m.Organization, m.Auth))
+	r.Handle("POST /organizations/{organizationID}/menus", middleware.Chain(h.MenuHandler.CreateShared, m.Organization, m.Auth))
+	r.Handle("POST /organizations/{organizationID}/menus/{menuID}/categories", middleware.Chain(h.MenuHandler.CreateSharedCategory, m.Organization, m.Auth))
+	r.Handle("POST /organizations/{organizationID}/categories/{categoryID}/articles", middleware.Chain(h.MenuHandler.CreateSharedArticle, m.Organization, m.Auth))
 
 	// Google
 	r.Handle("GET /google/autocomplete", middleware.Chain(h.GoogleHandler.Autocomplete, m.Auth))
 
+	// Admin
+	r.Handle("GET /admin/restaurant-verifications", middleware.Chain(h.RestaurantVerificationHandler.GetSubmitted, m.Admin, m.Auth))
+	r.Handle("POST /admin/restaurant-verifications/{id}/approve", middleware.Chain(h.RestaurantVerificationHandler.Approve, m.Admin, m.Auth))
+	r.Handle("POST /admin/restaurant-verifications/{id}/reject", middleware.Chain(h.RestaurantVerificationHandler.Reject, m.Admin, m.Auth))
+
 	// Sub-routes
 	apiV1 := http.NewServeMux()
 	apiV1.Handle("/api/v1/", http.StripPrefix("/api/v1", r))
@@ -21,6 +21,8 @@ func New(cfg *config.Container, h *handler.Handlers, m *middleware.Middleware) *
 
 	stack := middleware.CreateStack(
 		m.Logging,
+		m.ClientIP,
+		m.CSRF,
 	)
 
 	srv := &http.Server{
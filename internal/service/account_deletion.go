@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+var ErrAccountDeletionBlocked = errors.New("account cannot be deleted while you are the last owner of a restaurant")
+
+// anonymousEmail replaces the email of deleted users in the audit log, their ID is replaced with the nil UUID.
+const anonymousEmail = "deleted user"
+
+// AccountDeletionBlockedError lists the restaurants that would be left without an owner.
+type AccountDeletionBlockedError struct {
+	Restaurants []*dto.SoleOwnedRestaurant
+}
+
+func (e *AccountDeletionBlockedError) Error() string {
+	return ErrAccountDeletionBlocked.Error()
+}
+
+func (e *AccountDeletionBlockedError) Unwrap() error {
+	return ErrAccountDeletionBlocked
+}
+
+// AccountDeletionService schedules account deletions and hard-deletes the accounts once their grace period is over.
+type AccountDeletionService interface {
+	Schedule(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletion, error)
+	Cancel(ctx context.Context, userID uuid.UUID) (bool, error)
+	DeleteDue(ctx context.Context) error
+}
+
+type accountDeletionService struct {
+	cfg       *config.Jobs
+	db        *database.DB
+	mailerSvc MailerService
+}
+
+func NewAccountDeletionService(cfg *config.Jobs, db *database.DB, mailerSvc MailerService) *accountDeletionService {
+	return &accountDeletionService{
+		cfg:       cfg,
+		db:        db,
+		mailerSvc: mailerSvc,
+	}
+}
+
+// Schedule confirms the password and schedules the deletion at the end of the grace period. It returns an
+// AccountDeletionBlockedError when the user is the last owner of a restaurant.
+func (s *accountDeletionService) Schedule(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletion, error) {
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	if err := security.ComparePassword(dbUser.Password, password); err != nil {
+		return nil, ErrIncorrectPassword
+	}
+
+	if err := s.checkBlockers(ctx, s.db.Queries, userID); err != nil {
+		return nil, err
+	}
+
+	dbDeletion, err := s.db.Queries.ScheduleUserDeletion(ctx, repository.ScheduleUserDeletionParams{
+		UserID:      userID,
+		DeleteAfter: time.Now().Add(s.cfg.AccountDeletionGracePeriod),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scheduling deletion of user ID %s: %w", userID, err)
+	}
+	deletion := dto.NewAccountDeletion(&dbDeletion)
+
+	tmpl, err := s.mailerSvc.RenderTemplate("account_deletion.tmpl", map[string]any{
+		"User":        dto.NewUser(&dbUser),
+		"DeleteAfter": deletion.DeleteAfter,
+	})
+	if err != nil {
+		log.Printf("error rendering account deletion email for user ID %s: %v", userID, err)
+		return deletion, nil
+	}
+
+	err = s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{dbUser.Email},
+		Subject: "Your account is scheduled for deletion",
+		Body:    tmpl,
+	})
+	if err != nil {
+		log.Printf("error sending account deletion email to user ID %s: %v", userID, err)
+	}
+
+	return deletion, nil
+}
+
+// Cancel drops the pending deletion of the user and reports whether there was one.
+func (s *accountDeletionService) Cancel(ctx context.Context, userID uuid.UUID) (bool, error) {
+	canceled, err := s.db.Queries.CancelUserDeletion(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("error canceling deletion of user ID %s: %w", userID, err)
+	}
+
+	return canceled > 0, nil
+}
+
+// DeleteDue hard-deletes the accounts whose grace period is over. Accounts that became the last owner
+// of a restaurant in the meantime are kept until the ownership is handed over.
+func (s *accountDeletionService) DeleteDue(ctx context.Context) error {
+	dbDeletions, err := s.db.Queries.GetUserDeletionsDue(ctx, int32(s.cfg.AccountDeletionBatchSize))
+	if err != nil {
+		return fmt.Errorf("error fetching user deletions due: %w", err)
+	}
+
+	for i := range dbDeletions {
+		if err := s.delete(ctx, dbDeletions[i].UserID); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			log.Printf("error deleting user ID %s: %v", dbDeletions[i].UserID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *accountDeletionService) delete(ctx context.Context, userID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	if err := s.checkBlockers(ctx, qtx, userID); err != nil {
+		return err
+	}
+
+	dbUser, err := qtx.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	// Emails are matched as JSON strings so addresses containing the user's one are left untouched
+	email, err := json.Marshal(dbUser.Email)
+	if err != nil {
+		return err
+	}
+	anonymous, err := json.Marshal(anonymousEmail)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.AnonymizeUserAuditLogs(ctx, repository.AnonymizeUserAuditLogsParams{
+		UserID:         userID,
+		AnonymousID:    uuid.Nil.String(),
+		Email:          string(email),
+		AnonymousEmail: string(anonymous),
+	})
+	if err != nil {
+		return fmt.Errorf("error anonymizing audit logs of user ID %s: %w", userID, err)
+	}
+
+	// Transfers keep their history but can no longer be confirmed without the user
+	if err := qtx.CancelPendingOwnershipTransfersByUserID(ctx, &userID); err != nil {
+		return fmt.Errorf("error canceling pending ownership transfers of user ID %s: %w", userID, err)
+	}
+
+	if err := qtx.DeleteUser(ctx, userID); err != nil {
+		return fmt.Errorf("error deleting user ID %s: %w", userID, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *accountDeletionService) checkBlockers(ctx context.Context, q *repository.Queries, userID uuid.UUID) error {
+	dbRestaurants, err := q.GetSoleOwnedRestaurantsByUserID(ctx, repository.GetSoleOwnedRestaurantsByUserIDParams{
+		UserID: userID,
+		RoleID: int32(enum.RoleOwner),
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching restaurants solely owned by user ID %s: %w", userID, err)
+	}
+	if len(dbRestaurants) == 0 {
+		return nil
+	}
+
+	restaurants := make([]*dto.SoleOwnedRestaurant, len(dbRestaurants))
+	for i, restaurant := range dbRestaurants {
+		restaurants[i] = &dto.SoleOwnedRestaurant{
+			ID:   restaurant.ID,
+			Name: restaurant.Name,
+		}
+	}
+	return &AccountDeletionBlockedError{Restaurants: restaurants}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+var (
+	ErrInvalidAPIKey      = errors.New("invalid or expired API key")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKeyScope = errors.New("scope cannot be granted to an API key")
+	ErrAPIKeyExpiryInPast = errors.New("API key expiry must be in the future")
+)
+
+// API keys are formatted as rk_<prefix>_<secret>, the prefix is stored as is to find the key.
+const (
+	apiKeyMarker       = "rk_"
+	apiKeyPrefixLength = 8
+)
+
+// apiKeyScopes lists the permissions integrations can be granted, they never act on the team nor on the keys.
+var apiKeyScopes = []enum.Permission{
+	enum.PermissionRestaurantRead,
+	enum.PermissionRestaurantWrite,
+	enum.PermissionMenuRead,
+	enum.PermissionMenuWrite,
+	enum.PermissionMenuAvailability,
+	enum.PermissionReportsRead,
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, restaurantID, userID uuid.UUID, apiKey *dto.CreateAPIKey) (*dto.CreatedAPIKey, error)
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.APIKey, error)
+	Revoke(ctx context.Context, restaurantID uuid.UUID, id int) error
+	Authenticate(ctx context.Context, key string) (*dto.APIKey, error)
+}
+
+type apiKeyService struct {
+	db       *database.DB
+	auditSvc AuditService
+}
+
+func NewAPIKeyService(db *database.DB, auditSvc AuditService) *apiKeyService {
+	return &apiKeyService{
+		db:       db,
+		auditSvc: auditSvc,
+	}
+}
+
+// Create generates a key for the restaurant, the full key is only returned here.
+func (s *apiKeyService) Create(ctx context.Context, restaurantID, userID uuid.UUID, apiKey *dto.CreateAPIKey) (*dto.CreatedAPIKey, error) {
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		if !slices.Contains(apiKeyScopes, scope) {
+			return nil, ErrInvalidAPIKeyScope
+		}
+		if !slices.Contains(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+	slices.Sort(scopes)
+
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now()) {
+		return nil, ErrAPIKeyExpiryInPast
+	}
+
+	prefix, err := security.GenerateRandomString(apiKeyPrefixLength * 3 / 4)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := security.GenerateRandomString(24)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAPIKey, err := s.db.Queries.CreateRestaurantAPIKey(ctx, repository.CreateRestaurantAPIKeyParams{
+		RestaurantID:    restaurantID,
+		Name:            apiKey.Name,
+		Prefix:          prefix,
+		SecretHash:      hashAPIKeySecret(secret),
+		Scopes:          scopes,
+		CreatedByUserID: &userID,
+		ExpiresAt:       apiKey.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API key for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	created := dto.NewAPIKey(&dbAPIKey)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &userID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityAPIKey,
+		EntityID:     strconv.Itoa(created.ID),
+		After:        created,
+	})
+
+	return &dto.CreatedAPIKey{
+		APIKey: created,
+		Key:    apiKeyMarker + prefix + "_" + secret,
+	}, nil
+}
+
+func (s *apiKeyService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.APIKey, error) {
+	dbAPIKeys, err := s.db.Queries.GetRestaurantAPIKeysByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching API keys for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	apiKeys := make([]*dto.APIKey, len(dbAPIKeys))
+	for i := range dbAPIKeys {
+		apiKeys[i] = dto.NewAPIKey(&dbAPIKeys[i])
+	}
+	return apiKeys, nil
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, restaurantID uuid.UUID, id int) error {
+	dbAPIKey, err := s.db.Queries.RevokeRestaurantAPIKey(ctx, repository.RevokeRestaurantAPIKeyParams{
+		ID:           int32(id),
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("error revoking API key %d for restaurant ID %s: %w", id, restaurantID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionDelete,
+		EntityType:   enum.AuditEntityAPIKey,
+		EntityID:     strconv.Itoa(id),
+		Before:       dto.NewAPIKey(&dbAPIKey),
+	})
+
+	return nil
+}
+
+// Authenticate returns the key matching the full key, revoked and expired keys are refused.
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*dto.APIKey, error) {
+	rest, ok := strings.CutPrefix(key, apiKeyMarker)
+	if !ok || len(rest) <= apiKeyPrefixLength+1 || rest[apiKeyPrefixLength] != '_' {
+		return nil, ErrInvalidAPIKey
+	}
+	prefix, secret := rest[:apiKeyPrefixLength], rest[apiKeyPrefixLength+1:]
+
+	dbAPIKey, err := s.db.Queries.GetRestaurantAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("error fetching API key by prefix %s: %w", prefix, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(dbAPIKey.SecretHash), []byte(hashAPIKeySecret(secret))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+	if dbAPIKey.RevokedAt != nil || (dbAPIKey.ExpiresAt != nil && !dbAPIKey.ExpiresAt.After(time.Now())) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.db.Queries.TouchRestaurantAPIKey(ctx, dbAPIKey.ID); err != nil {
+		log.Printf("error updating last use of API key %d: %v", dbAPIKey.ID, err)
+	}
+
+	return dto.NewAPIKey(&dbAPIKey), nil
+}
+
+// hashAPIKeySecret uses a fast hash, the secret is random enough not to need a password hash.
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entry *dto.CreateAuditLog)
+	RecordForOrganization(ctx context.Context, organizationID uuid.UUID, entry *dto.CreateAuditLog)
+	GetByRestaurantID(ctx context.Context, filter dto.AuditLogFilter) (*dto.AuditLogPage, error)
+}
+
+type auditService struct {
+	db *database.DB
+}
+
+func NewAuditService(db *database.DB) *auditService {
+	return &auditService{
+		db: db,
+	}
+}
+
+// Record appends the entry once the change is committed, a failure is logged and never undoes the change.
+func (s *auditService) Record(ctx context.Context, entry *dto.CreateAuditLog) {
+	actorUserID := entry.ActorUserID
+	if actorUserID == nil {
+		if userID, err := keys.GetUserIDFromContext(ctx); err == nil {
+			actorUserID = &userID
+		}
+	}
+
+	var actorAPIKeyID *int32
+	if actorUserID == nil {
+		if apiKeyID, err := keys.GetAPIKeyIDFromContext(ctx); err == nil {
+			id := int32(apiKeyID)
+			actorAPIKeyID = &id
+		}
+	}
+
+	before, err := marshalAuditData(entry.Before)
+	if err != nil {
+		log.Printf("error encoding audit %s of %s %s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+		return
+	}
+	after, err := marshalAuditData(entry.After)
+	if err != nil {
+		log.Printf("error encoding audit %s of %s %s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+		return
+	}
+
+	err = s.db.Queries.CreateAuditLog(ctx, repository.CreateAuditLogParams{
+		RestaurantID:  entry.RestaurantID,
+		ActorUserID:   actorUserID,
+		ActorApiKeyID: actorAPIKeyID,
+		Action:        string(entry.Action),
+		EntityType:    string(entry.EntityType),
+		EntityID:      entry.EntityID,
+		BeforeData:    before,
+		AfterData:     after,
+	})
+	if err != nil {
+		log.Printf("error recording audit %s of %s %s for restaurant ID %s: %v", entry.Action, entry.EntityType, entry.EntityID, entry.RestaurantID, err)
+	}
+}
+
+// RecordForOrganization appends the entry to every restaurant of the organization, the change applies to all of them.
+func (s *auditService) RecordForOrganization(ctx context.Context, organizationID uuid.UUID, entry *dto.CreateAuditLog) {
+	dbRestaurants, err := s.db.Queries.GetRestaurantsByOrganizationID(ctx, &organizationID)
+	if err != nil {
+		log.Printf("error fetching restaurants of organization ID %s to record audit %s of %s %s: %v", organizationID, entry.Action, entry.EntityType, entry.EntityID, err)
+		return
+	}
+
+	for _, dbRestaurant := range dbRestaurants {
+		restaurantEntry := *entry
+		restaurantEntry.RestaurantID = dbRestaurant.ID
+		s.Record(ctx, &restaurantEntry)
+	}
+}
+
+func (s *auditService) GetByRestaurantID(ctx context.Context, filter dto.AuditLogFilter) (*dto.AuditLogPage, error) {
+	dbLogs, err := s.db.Queries.GetAuditLogsByRestaurantID(ctx, filter.ToParams())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching audit log for restaurant ID %s: %w", filter.RestaurantID, err)
+	}
+
+	page := &dto.AuditLogPage{
+		Entries: make([]*dto.AuditLog, 0, len(dbLogs)),
+	}
+	if len(dbLogs) > filter.Limit {
+		dbLogs = dbLogs[:filter.Limit]
+		page.NextCursor = &dbLogs[len(dbLogs)-1].ID
+	}
+	for i := range dbLogs {
+		page.Entries = append(page.Entries, dto.NewAuditLog(&dbLogs[i]))
+	}
+	return page, nil
+}
+
+// marshalAuditData encodes a snapshot of the entity, nil values including typed nil pointers are stored as NULL.
+func marshalAuditData(data any) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "null" {
+		return nil, nil
+	}
+	return encoded, nil
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/config"
 	"github.com/memsbdm/restaurant-api/internal/cache"
 	"github.com/memsbdm/restaurant-api/internal/dto"
@@ -13,32 +17,58 @@ import (
 
 var ErrInvalidCredentials = errors.New("invalid credentials")
 
+const (
+	maxTwoFactorAttempts      = 5
+	passwordResetEmailTimeout = 30 * time.Second
+)
+
 type AuthService interface {
-	Register(ctx context.Context, user *dto.CreateUser) (*dto.User, string, error)
-	Login(ctx context.Context, email, password string) (*dto.LoginResponse, string, error)
-	Logout(ctx context.Context, oat string) error
-	ResetAuthOATCacheTTL(ctx context.Context, oat string, userID string) error
+	Register(ctx context.Context, user *dto.CreateUser, client *dto.SessionClient) (*dto.User, string, error)
+	Login(ctx context.Context, email, password string, client *dto.SessionClient) (*dto.LoginResponse, string, error)
+	LoginWithOIDC(ctx context.Context, code, state string, client *dto.SessionClient) (*dto.LoginResponse, string, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string, client *dto.SessionClient) (*dto.LoginResponse, string, error)
+	Logout(ctx context.Context, userID uuid.UUID, oat string) error
+	IssueRefreshToken(ctx context.Context, userID uuid.UUID, oat string) (string, error)
+	Refresh(ctx context.Context, refreshToken string, client *dto.SessionClient) (string, string, error)
+	ResetAuthOATCacheTTL(ctx context.Context, oat string, userID string, client *dto.SessionClient) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, oat, currentPassword, newPassword string) error
+	LogoutEverywhere(ctx context.Context, userID uuid.UUID) error
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletion, error)
 }
 
 type authService struct {
-	cfg               *config.Security
-	cache             cache.Cache
-	restaurantService RestaurantService
-	tokenService      TokenService
-	userService       UserService
+	cfg                    *config.Security
+	cache                  cache.Cache
+	accountDeletionService AccountDeletionService
+	loginAttemptService    LoginAttemptService
+	oidcService            OIDCService
+	refreshTokenService    RefreshTokenService
+	restaurantService      RestaurantService
+	sessionService         SessionService
+	tokenService           TokenService
+	twoFactorService       TwoFactorService
+	userService            UserService
 }
 
-func NewAuthService(cfg *config.Security, cache cache.Cache, userService UserService, tokenService TokenService, restaurantService RestaurantService) *authService {
+func NewAuthService(cfg *config.Security, cache cache.Cache, userService UserService, tokenService TokenService, restaurantService RestaurantService, sessionService SessionService, refreshTokenService RefreshTokenService, oidcService OIDCService, twoFactorService TwoFactorService, loginAttemptService LoginAttemptService, accountDeletionService AccountDeletionService) *authService {
 	return &authService{
-		cfg:               cfg,
-		cache:             cache,
-		restaurantService: restaurantService,
-		tokenService:      tokenService,
-		userService:       userService,
+		cfg:                    cfg,
+		cache:                  cache,
+		accountDeletionService: accountDeletionService,
+		loginAttemptService:    loginAttemptService,
+		oidcService:            oidcService,
+		refreshTokenService:    refreshTokenService,
+		restaurantService:      restaurantService,
+		sessionService:         sessionService,
+		tokenService:           tokenService,
+		twoFactorService:       twoFactorService,
+		userService:            userService,
 	}
 }
 
-func (s *authService) Register(ctx context.Context, user *dto.CreateUser) (*dto.User, string, error) {
+func (s *authService) Register(ctx context.Context, user *dto.CreateUser, client *dto.SessionClient) (*dto.User, string, error) {
 	createdUser, err := s.userService.Create(ctx, user)
 	if err != nil {
 		return nil, "", err
@@ -48,7 +78,7 @@ func (s *authService) Register(ctx context.Context, user *dto.CreateUser) (*dto.
 		return nil, "", err
 	}
 
-	oat, err := s.tokenService.GenerateOAT(ctx, keys.AuthToken, createdUser.ID.String(), keys.AuthTokenDuration)
+	oat, err := s.issueOAT(ctx, createdUser.ID, client)
 	if err != nil {
 		return nil, "", err
 	}
@@ -56,23 +86,140 @@ func (s *authService) Register(ctx context.Context, user *dto.CreateUser) (*dto.
 	return createdUser, oat, nil
 }
 
-func (s *authService) Login(ctx context.Context, email, password string) (*dto.LoginResponse, string, error) {
+// Login checks the credentials, failed attempts are counted and lock the email or the IP out once too many.
+func (s *authService) Login(ctx context.Context, email, password string, client *dto.SessionClient) (*dto.LoginResponse, string, error) {
+	if err := s.loginAttemptService.Check(ctx, email, client.IP); err != nil {
+		return nil, "", err
+	}
+
 	fetchedUser, err := s.userService.GetByEmail(ctx, email)
 	if err != nil {
+		if err := s.loginAttemptService.RecordFailure(ctx, email, client.IP, nil); err != nil {
+			return nil, "", err
+		}
 		return nil, "", ErrInvalidCredentials
 	}
 
 	err = security.ComparePassword(fetchedUser.Password, password)
 	if err != nil {
+		if err := s.loginAttemptService.RecordFailure(ctx, email, client.IP, fetchedUser); err != nil {
+			return nil, "", err
+		}
 		return nil, "", ErrInvalidCredentials
 	}
 
-	oat, err := s.tokenService.GenerateOAT(ctx, keys.AuthToken, fetchedUser.ID.String(), keys.AuthTokenDuration)
+	if err := s.loginAttemptService.Reset(ctx, email); err != nil {
+		return nil, "", err
+	}
+
+	return s.logIn(ctx, fetchedUser, client)
+}
+
+// LoginWithOIDC completes the OpenID Connect flow and logs in the user linked to the identity.
+func (s *authService) LoginWithOIDC(ctx context.Context, code, state string, client *dto.SessionClient) (*dto.LoginResponse, string, error) {
+	identity, err := s.oidcService.Exchange(ctx, code, state)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.userService.GetOrCreateByIdentity(ctx, identity)
 	if err != nil {
 		return nil, "", err
 	}
 
-	restaurants, err := s.restaurantService.GetRestaurantsByUserID(ctx, fetchedUser.ID)
+	return s.logIn(ctx, user, client)
+}
+
+// VerifyTwoFactor opens the session of a login challenged for a second factor.
+// The challenge is revoked after too many invalid codes, and the user is locked out once too many across challenges.
+func (s *authService) VerifyTwoFactor(ctx context.Context, challengeToken, code string, client *dto.SessionClient) (*dto.LoginResponse, string, error) {
+	userIDStr, err := s.tokenService.VerifyOAT(ctx, keys.TwoFactorChallenge, challengeToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	challenge, err := decodeOAT(challengeToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, "", ErrInvalidToken
+	}
+
+	if err := s.loginAttemptService.CheckTwoFactor(ctx, userID); err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = s.twoFactorService.Verify(ctx, userID, code)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			return nil, "", err
+		}
+
+		if err := s.loginAttemptService.RecordTwoFactorFailure(ctx, user); err != nil {
+			return nil, "", err
+		}
+
+		attempts, err := s.cache.Increment(ctx, cache.GenerateKey(string(keys.TwoFactorChallenge)+"_attempts", challenge), keys.TwoFactorChallengeDuration)
+		if err != nil {
+			return nil, "", err
+		}
+		if attempts >= maxTwoFactorAttempts {
+			if err := s.tokenService.RevokeOAT(ctx, keys.TwoFactorChallenge, userIDStr, challenge); err != nil {
+				return nil, "", err
+			}
+		}
+		return nil, "", ErrInvalidTwoFactorCode
+	}
+
+	if err := s.tokenService.RevokeOAT(ctx, keys.TwoFactorChallenge, userIDStr, challenge); err != nil {
+		return nil, "", err
+	}
+
+	if err := s.loginAttemptService.ResetTwoFactor(ctx, userID); err != nil {
+		return nil, "", err
+	}
+
+	return s.openSession(ctx, user, client)
+}
+
+// logIn opens a session for an authenticated user, or returns a challenge when two-factor authentication is enabled.
+func (s *authService) logIn(ctx context.Context, user *dto.User, client *dto.SessionClient) (*dto.LoginResponse, string, error) {
+	enabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if enabled {
+		challengeToken, err := s.tokenService.GenerateOAT(ctx, keys.TwoFactorChallenge, user.ID.String(), keys.TwoFactorChallengeDuration)
+		if err != nil {
+			return nil, "", err
+		}
+		return &dto.LoginResponse{ChallengeToken: challengeToken}, "", nil
+	}
+
+	return s.openSession(ctx, user, client)
+}
+
+// openSession signs the user in, which cancels the deletion of their account if one is pending.
+func (s *authService) openSession(ctx context.Context, user *dto.User, client *dto.SessionClient) (*dto.LoginResponse, string, error) {
+	if _, err := s.accountDeletionService.Cancel(ctx, user.ID); err != nil {
+		return nil, "", err
+	}
+
+	oat, err := s.issueOAT(ctx, user.ID, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	restaurants, err := s.restaurantService.GetRestaurantsByUserID(ctx, user.ID)
 	if err != nil {
 		if !errors.Is(err, ErrNoRestaurantFoundForUser) {
 			return nil, "", err
@@ -80,17 +227,180 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 	}
 
 	loginResponse := &dto.LoginResponse{
-		User:        fetchedUser,
+		User:        user,
 		Restaurants: restaurants,
 	}
 
 	return loginResponse, oat, nil
 }
 
-func (s *authService) Logout(ctx context.Context, oat string) error {
-	return s.cache.Delete(ctx, cache.GenerateKey(string(keys.AuthToken), oat))
+func (s *authService) Logout(ctx context.Context, userID uuid.UUID, oat string) error {
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.AuthToken), oat)); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenService.RevokeByOAT(ctx, userID.String(), oat); err != nil {
+		return err
+	}
+
+	return s.sessionService.Delete(ctx, oat)
+}
+
+// LogoutEverywhere revokes every OAT of the user, including the one of the current session.
+func (s *authService) LogoutEverywhere(ctx context.Context, userID uuid.UUID) error {
+	return s.revokeSessions(ctx, userID)
+}
+
+// DeleteAccount schedules the deletion of the account and signs the user out everywhere.
+func (s *authService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (*dto.AccountDeletion, error) {
+	deletion, err := s.accountDeletionService.Schedule(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.revokeSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+// IssueRefreshToken starts a refresh token family for the session opened with the OAT.
+func (s *authService) IssueRefreshToken(ctx context.Context, userID uuid.UUID, oat string) (string, error) {
+	rawOAT, err := decodeOAT(oat)
+	if err != nil {
+		return "", err
+	}
+
+	return s.refreshTokenService.Issue(ctx, userID.String(), rawOAT)
+}
+
+// Refresh exchanges a refresh token for a new OAT and the next refresh token of the family.
+// The OAT previously issued by the family is revoked.
+func (s *authService) Refresh(ctx context.Context, refreshToken string, client *dto.SessionClient) (string, string, error) {
+	family, err := s.refreshTokenService.Consume(ctx, refreshToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	userID, err := uuid.Parse(family.UserID)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+
+	oat, err := s.issueOAT(ctx, userID, client)
+	if err != nil {
+		return "", "", err
+	}
+
+	rawOAT, err := decodeOAT(oat)
+	if err != nil {
+		return "", "", err
+	}
+
+	previousOAT := family.OAT
+	newRefreshToken, err := s.refreshTokenService.Rotate(ctx, family, rawOAT)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.tokenService.RevokeOAT(ctx, keys.AuthToken, family.UserID, previousOAT); err != nil {
+		return "", "", err
+	}
+
+	if err := s.sessionService.Delete(ctx, previousOAT); err != nil {
+		return "", "", err
+	}
+
+	return oat, newRefreshToken, nil
+}
+
+// ResetAuthOATCacheTTL slides the expiry of the OAT, its index entry and its session together.
+func (s *authService) ResetAuthOATCacheTTL(ctx context.Context, oat string, userID string, client *dto.SessionClient) error {
+	// The OAT is never recreated, a request in flight must not bring back a revoked session
+	err := s.cache.Expire(ctx, cache.GenerateKey(string(keys.AuthToken), oat), keys.AuthTokenDuration)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+
+	err = s.cache.AddToSet(ctx, oatIndexKey(keys.AuthToken, userID), oat, keys.AuthTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	return s.sessionService.Track(ctx, oat, client)
+}
+
+// issueOAT generates an auth OAT for the user and records the session it opens.
+func (s *authService) issueOAT(ctx context.Context, userID uuid.UUID, client *dto.SessionClient) (string, error) {
+	oat, err := s.tokenService.GenerateOAT(ctx, keys.AuthToken, userID.String(), keys.AuthTokenDuration)
+	if err != nil {
+		return "", err
+	}
+
+	rawOAT, err := decodeOAT(oat)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.sessionService.Track(ctx, rawOAT, client); err != nil {
+		return "", err
+	}
+
+	return oat, nil
+}
+
+// ForgotPassword sends a password reset email when an account exists for the email.
+// Unknown emails and delivery failures are not reported to avoid account enumeration.
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userService.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	// The email is sent in the background so the response time does not tell whether the email is registered
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetEmailTimeout)
+		defer cancel()
+
+		if err := s.userService.SendPasswordResetEmail(ctx, user); err != nil {
+			log.Printf("error sending password reset email to user ID %s: %v", user.ID, err)
+		}
+	}()
+
+	return nil
 }
 
-func (s *authService) ResetAuthOATCacheTTL(ctx context.Context, oat string, userID string) error {
-	return s.cache.Set(ctx, cache.GenerateKey(string(keys.AuthToken), oat), []byte(userID), keys.AuthTokenDuration)
+// ResetPassword sets the new password and signs the user out of every session.
+func (s *authService) ResetPassword(ctx context.Context, token, password string) error {
+	userID, err := s.userService.ResetPassword(ctx, token, password)
+	if err != nil {
+		return err
+	}
+
+	return s.revokeSessions(ctx, userID)
+}
+
+// ChangePassword updates the password and signs the user out of every session except the current one.
+func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oat, currentPassword, newPassword string) error {
+	if err := s.userService.ChangePassword(ctx, userID, currentPassword, newPassword); err != nil {
+		return err
+	}
+
+	return s.revokeSessions(ctx, userID, oat)
+}
+
+// revokeSessions revokes the OATs and refresh tokens of the user, except the sessions opened with exceptOATs.
+func (s *authService) revokeSessions(ctx context.Context, userID uuid.UUID, exceptOATs ...string) error {
+	if err := s.tokenService.RevokeOATs(ctx, keys.AuthToken, userID.String(), exceptOATs...); err != nil {
+		return err
+	}
+
+	return s.refreshTokenService.RevokeByUserID(ctx, userID.String(), exceptOATs...)
 }
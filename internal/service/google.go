@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -92,7 +93,7 @@ func (s *googleService) Autocomplete(ctx context.Context, query string) ([]*dto.
 func (s *googleService) GetDetails(ctx context.Context, placeID string) (*dto.CreateRestaurant, error) {
 	const apiURL = "https://places.googleapis.com/v1/places/"
 	params := url.Values{}
-	params.Set("fields", "displayName,formattedAddress,location,internationalPhoneNumber")
+	params.Set("fields", "displayName,formattedAddress,location,internationalPhoneNumber,addressComponents,timeZone")
 	params.Set("key", s.cfg.APIKey)
 	reqURL := fmt.Sprintf("%s%s?%s", apiURL, placeID, params.Encode())
 
@@ -121,6 +122,13 @@ func (s *googleService) GetDetails(ctx context.Context, placeID string) (*dto.Cr
 			Text        string `json:"text"`
 			LangageCode string `json:"languageCode"`
 		} `json:"displayName"`
+		AddressComponents []struct {
+			ShortText string   `json:"shortText"`
+			Types     []string `json:"types"`
+		} `json:"addressComponents"`
+		TimeZone *struct {
+			ID string `json:"id"`
+		} `json:"timeZone"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -139,8 +147,21 @@ func (s *googleService) GetDetails(ctx context.Context, placeID string) (*dto.Cr
 		restaurant.Lng = result.Location.Lng
 	}
 
-	formattedPhone := strings.ReplaceAll(*result.InternationalPhoneNumber, " ", "")
-	restaurant.Phone = &formattedPhone
+	for _, component := range result.AddressComponents {
+		if slices.Contains(component.Types, "country") {
+			restaurant.CountryCode = component.ShortText
+			break
+		}
+	}
+
+	if result.TimeZone != nil {
+		restaurant.Timezone = result.TimeZone.ID
+	}
+
+	if result.InternationalPhoneNumber != nil {
+		formattedPhone := strings.ReplaceAll(*result.InternationalPhoneNumber, " ", "")
+		restaurant.Phone = &formattedPhone
+	}
 
 	return restaurant, nil
 }
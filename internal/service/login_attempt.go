@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/cache"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts, please try again later")
+
+// lockoutsMemory is how long past lockouts keep doubling the duration of the next one.
+const lockoutsMemory = 24 * time.Hour
+
+// LoginLockedError carries the time left before logging in is allowed again.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
+
+// LoginAttemptService counts failed logins per email and per IP and locks them out once a limit is reached.
+// Invalid second factor codes are counted per user, so a new challenge does not bring a new budget.
+type LoginAttemptService interface {
+	Check(ctx context.Context, email, ip string) error
+	RecordFailure(ctx context.Context, email, ip string, user *dto.User) error
+	Reset(ctx context.Context, email string) error
+	CheckTwoFactor(ctx context.Context, userID uuid.UUID) error
+	RecordTwoFactorFailure(ctx context.Context, user *dto.User) error
+	ResetTwoFactor(ctx context.Context, userID uuid.UUID) error
+}
+
+type loginAttemptService struct {
+	cfg       *config.Security
+	cache     cache.Cache
+	mailerSvc MailerService
+}
+
+func NewLoginAttemptService(cfg *config.Security, cache cache.Cache, mailerSvc MailerService) *loginAttemptService {
+	return &loginAttemptService{
+		cfg:       cfg,
+		cache:     cache,
+		mailerSvc: mailerSvc,
+	}
+}
+
+// Check returns a LoginLockedError when the email or the IP is locked out.
+func (s *loginAttemptService) Check(ctx context.Context, email, ip string) error {
+	for _, subject := range loginSubjects(email, ip) {
+		retryAfter, err := s.getLockout(ctx, subject)
+		if err != nil {
+			return err
+		}
+		if retryAfter > 0 {
+			return &LoginLockedError{RetryAfter: retryAfter}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts the failed login. Reaching the limit locks the email or the IP out, every lockout
+// within a day doubles the duration of the next one. The user, when known, is warned about email lockouts.
+func (s *loginAttemptService) RecordFailure(ctx context.Context, email, ip string, user *dto.User) error {
+	subjects := loginSubjects(email, ip)
+	limits := []int{s.cfg.LoginMaxAttempts, s.cfg.LoginMaxAttemptsPerIP}
+
+	for i, subject := range subjects {
+		failures, err := s.cache.Increment(ctx, cache.GenerateKey(string(keys.LoginFailures), subject), s.cfg.LoginAttemptWindow)
+		if err != nil {
+			return err
+		}
+		if failures < int64(limits[i]) {
+			continue
+		}
+
+		duration, err := s.lockOut(ctx, subject)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 && user != nil {
+			if err := s.sendLockoutEmail(user, duration); err != nil {
+				log.Printf("error sending lockout email to user ID %s: %v", user.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reset forgets the failed logins of the email after a successful login, IP counters are kept.
+func (s *loginAttemptService) Reset(ctx context.Context, email string) error {
+	return s.cache.Delete(ctx, cache.GenerateKey(string(keys.LoginFailures), loginSubjects(email, "")[0]))
+}
+
+// CheckTwoFactor returns a LoginLockedError when the user is locked out of the second factor.
+func (s *loginAttemptService) CheckTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	retryAfter, err := s.getLockout(ctx, twoFactorSubject(userID))
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// RecordTwoFactorFailure counts the invalid code against the user and locks them out the same way as emails,
+// the user is warned since their password is known.
+func (s *loginAttemptService) RecordTwoFactorFailure(ctx context.Context, user *dto.User) error {
+	subject := twoFactorSubject(user.ID)
+	failures, err := s.cache.Increment(ctx, cache.GenerateKey(string(keys.LoginFailures), subject), s.cfg.LoginAttemptWindow)
+	if err != nil {
+		return err
+	}
+	if failures < int64(s.cfg.LoginMaxAttempts) {
+		return nil
+	}
+
+	duration, err := s.lockOut(ctx, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sendLockoutEmail(user, duration); err != nil {
+		log.Printf("error sending lockout email to user ID %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// ResetTwoFactor forgets the invalid codes of the user after a successful second factor.
+func (s *loginAttemptService) ResetTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	return s.cache.Delete(ctx, cache.GenerateKey(string(keys.LoginFailures), twoFactorSubject(userID)))
+}
+
+func (s *loginAttemptService) lockOut(ctx context.Context, subject string) (time.Duration, error) {
+	lockouts, err := s.cache.Increment(ctx, cache.GenerateKey(string(keys.LoginLockouts), subject), lockoutsMemory)
+	if err != nil {
+		return 0, err
+	}
+
+	duration := s.cfg.LoginLockoutDuration
+	for i := int64(1); i < lockouts && duration < s.cfg.LoginMaxLockoutDuration; i++ {
+		duration *= 2
+	}
+	duration = min(duration, s.cfg.LoginMaxLockoutDuration)
+
+	lockedUntil := time.Now().Add(duration).Unix()
+	err = s.cache.Set(ctx, cache.GenerateKey(string(keys.LoginLockout), subject), []byte(strconv.FormatInt(lockedUntil, 10)), duration)
+	if err != nil {
+		return 0, err
+	}
+
+	// The next lockout only starts after a full series of failures
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.LoginFailures), subject)); err != nil {
+		return 0, err
+	}
+
+	return duration, nil
+}
+
+func (s *loginAttemptService) getLockout(ctx context.Context, subject string) (time.Duration, error) {
+	data, err := s.cache.Get(ctx, cache.GenerateKey(string(keys.LoginLockout), subject))
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	lockedUntil, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing lockout of %s: %w", subject, err)
+	}
+
+	return time.Until(time.Unix(lockedUntil, 0)), nil
+}
+
+func (s *loginAttemptService) sendLockoutEmail(user *dto.User, duration time.Duration) error {
+	emailtmpl, err := s.mailerSvc.RenderTemplate("account_locked.tmpl", map[string]any{
+		"User":     user,
+		"Duration": formatLockoutDuration(duration),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{user.Email},
+		Subject: "Failed login attempts on your account",
+		Body:    emailtmpl,
+	})
+}
+
+func formatLockoutDuration(duration time.Duration) string {
+	minutes := int(duration.Round(time.Minute).Minutes())
+	if minutes <= 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+func twoFactorSubject(userID uuid.UUID) string {
+	return "two_factor:" + userID.String()
+}
+
+// loginSubjects returns the counter subjects of the email and of the IP, in this order.
+func loginSubjects(email, ip string) []string {
+	return []string{
+		"email:" + strings.ToLower(strings.TrimSpace(email)),
+		"ip:" + ip,
+	}
+}
@@ -2,30 +2,58 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
 	"github.com/memsbdm/restaurant-api/internal/database/repository"
 	"github.com/memsbdm/restaurant-api/internal/dto"
 )
 
+var (
+	ErrMenuNotFound              = errors.New("menu not found")
+	ErrCategoryNotFound          = errors.New("category not found")
+	ErrArticleNotFound           = errors.New("article not found")
+	ErrArticleNotInherited       = errors.New("only articles of a shared menu can have a price override")
+	ErrArticlePriceOverrideUnset = errors.New("article has no price override")
+)
+
 type MenuService interface {
 	Create(ctx context.Context, name string, restaurantID uuid.UUID) (*dto.Menu, error)
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.Menu, error)
+	CreateShared(ctx context.Context, name string, organizationID uuid.UUID) (*dto.Menu, error)
+	GetSharedByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*dto.Menu, error)
+	CreateSharedCategory(ctx context.Context, organizationID uuid.UUID, menuID int, category *dto.CreateCategory) (*dto.Category, error)
+	CreateSharedArticle(ctx context.Context, organizationID uuid.UUID, categoryID int, article *dto.CreateArticle) (*dto.Article, error)
+	SetPriceOverride(ctx context.Context, restaurantID uuid.UUID, articleID int, price float64) (*dto.ArticlePriceOverride, error)
+	DeletePriceOverride(ctx context.Context, restaurantID uuid.UUID, articleID int) error
+	SetAvailability(ctx context.Context, restaurantID uuid.UUID, articleID int, isAvailable bool) error
 }
 
 type menuService struct {
-	db *database.DB
+	db       *database.DB
+	auditSvc AuditService
 }
 
-func NewMenuService(db *database.DB) *menuService {
+func NewMenuService(db *database.DB, auditSvc AuditService) *menuService {
 	return &menuService{
-		db: db,
+		db:       db,
+		auditSvc: auditSvc,
 	}
 }
 
+// articleAvailabilityAuditData is the availability snapshot stored in the audit log.
+type articleAvailabilityAuditData struct {
+	ArticleID   int  `json:"article_id"`
+	IsAvailable bool `json:"is_available"`
+}
+
 func (s *menuService) Create(ctx context.Context, name string, restaurantID uuid.UUID) (*dto.Menu, error) {
-	menuAlreadyExists, err := s.db.Queries.MenuExistsForRestaurantID(ctx, restaurantID)
+	menuAlreadyExists, err := s.db.Queries.MenuExistsForRestaurantID(ctx, &restaurantID)
 	if err != nil {
 		return nil, fmt.Errorf("error checking if menu exists for restaurant ID %s: %w", restaurantID, err)
 	}
@@ -33,10 +61,303 @@ func (s *menuService) Create(ctx context.Context, name string, restaurantID uuid
 	dbCreatedMenu, err := s.db.Queries.CreateMenu(ctx, repository.CreateMenuParams{
 		Name:         name,
 		IsActive:     !menuAlreadyExists,
-		RestaurantID: restaurantID,
+		RestaurantID: &restaurantID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error creating menu for restaurant ID %s: %w", restaurantID, err)
 	}
-	return dto.NewMenu(&dbCreatedMenu), nil
+
+	created := dto.NewMenu(&dbCreatedMenu)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityMenu,
+		EntityID:     strconv.Itoa(int(dbCreatedMenu.ID)),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+// GetByRestaurantID returns the restaurant menus followed by the ones shared by its organization,
+// articles carry the restaurant price.
+func (s *menuService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.Menu, error) {
+	dbMenus, err := s.db.Queries.GetMenusByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching menus for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	menuIDs := make([]int32, len(dbMenus))
+	for i := range dbMenus {
+		menuIDs[i] = dbMenus[i].ID
+	}
+
+	dbCategories, err := s.db.Queries.GetCategoriesByMenuIDs(ctx, menuIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching categories for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	dbArticles, err := s.db.Queries.GetArticlesByMenuIDsForRestaurant(ctx, repository.GetArticlesByMenuIDsForRestaurantParams{
+		RestaurantID: restaurantID,
+		MenuIds:      menuIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching articles for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	articlesByCategory := make(map[int32][]dto.Article)
+	for i := range dbArticles {
+		articlesByCategory[dbArticles[i].CategoryID] = append(articlesByCategory[dbArticles[i].CategoryID], *dto.NewRestaurantArticle(&dbArticles[i]))
+	}
+
+	categoriesByMenu := make(map[int32][]dto.Category)
+	for i := range dbCategories {
+		category := dto.NewCategory(&dbCategories[i])
+		if articles, ok := articlesByCategory[dbCategories[i].ID]; ok {
+			category.Articles = articles
+		}
+		categoriesByMenu[dbCategories[i].MenuID] = append(categoriesByMenu[dbCategories[i].MenuID], *category)
+	}
+
+	menus := make([]*dto.Menu, len(dbMenus))
+	for i := range dbMenus {
+		menus[i] = dto.NewMenu(&dbMenus[i])
+		menus[i].Categories = categoriesByMenu[dbMenus[i].ID]
+	}
+	return menus, nil
+}
+
+func (s *menuService) CreateShared(ctx context.Context, name string, organizationID uuid.UUID) (*dto.Menu, error) {
+	dbCreatedMenu, err := s.db.Queries.CreateOrganizationMenu(ctx, repository.CreateOrganizationMenuParams{
+		Name:           name,
+		OrganizationID: &organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating menu for organization ID %s: %w", organizationID, err)
+	}
+
+	created := dto.NewMenu(&dbCreatedMenu)
+	s.auditSvc.RecordForOrganization(ctx, organizationID, &dto.CreateAuditLog{
+		Action:     enum.AuditActionCreate,
+		EntityType: enum.AuditEntityMenu,
+		EntityID:   strconv.Itoa(int(dbCreatedMenu.ID)),
+		After:      created,
+	})
+
+	return created, nil
+}
+
+func (s *menuService) GetSharedByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*dto.Menu, error) {
+	dbMenus, err := s.db.Queries.GetMenusByOrganizationID(ctx, &organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching menus for organization ID %s: %w", organizationID, err)
+	}
+
+	menus := make([]*dto.Menu, len(dbMenus))
+	for i := range dbMenus {
+		menus[i] = dto.NewMenu(&dbMenus[i])
+	}
+	return menus, nil
+}
+
+func (s *menuService) CreateSharedCategory(ctx context.Context, organizationID uuid.UUID, menuID int, category *dto.CreateCategory) (*dto.Category, error) {
+	_, err := s.db.Queries.GetOrganizationMenuByID(ctx, repository.GetOrganizationMenuByIDParams{
+		ID:             int32(menuID),
+		OrganizationID: &organizationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMenuNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu %d for organization ID %s: %w", menuID, organizationID, err)
+	}
+
+	dbCategory, err := s.db.Queries.CreateCategory(ctx, repository.CreateCategoryParams{
+		Name:          category.Name,
+		Description:   category.Description,
+		CategoryOrder: int16(category.CategoryOrder),
+		MenuID:        int32(menuID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating category for menu %d: %w", menuID, err)
+	}
+
+	created := dto.NewCategory(&dbCategory)
+	s.auditSvc.RecordForOrganization(ctx, organizationID, &dto.CreateAuditLog{
+		Action:     enum.AuditActionCreate,
+		EntityType: enum.AuditEntityCategory,
+		EntityID:   strconv.Itoa(int(dbCategory.ID)),
+		After:      created,
+	})
+
+	return created, nil
+}
+
+func (s *menuService) CreateSharedArticle(ctx context.Context, organizationID uuid.UUID, categoryID int, article *dto.CreateArticle) (*dto.Article, error) {
+	dbCategory, err := s.db.Queries.GetCategoryByID(ctx, int32(categoryID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("error fetching category %d: %w", categoryID, err)
+	}
+
+	_, err = s.db.Queries.GetOrganizationMenuByID(ctx, repository.GetOrganizationMenuByIDParams{
+		ID:             dbCategory.MenuID,
+		OrganizationID: &organizationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("error fetching menu %d for organization ID %s: %w", dbCategory.MenuID, organizationID, err)
+	}
+
+	dbArticle, err := s.db.Queries.CreateArticle(ctx, repository.CreateArticleParams{
+		Name:         article.Name,
+		Description:  article.Description,
+		Price:        article.Price,
+		ArticleOrder: int16(article.ArticleOrder),
+		CategoryID:   int32(categoryID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating article for category %d: %w", categoryID, err)
+	}
+
+	created := dto.NewArticle(&dbArticle)
+	s.auditSvc.RecordForOrganization(ctx, organizationID, &dto.CreateAuditLog{
+		Action:     enum.AuditActionCreate,
+		EntityType: enum.AuditEntityArticle,
+		EntityID:   strconv.Itoa(int(dbArticle.ID)),
+		After:      created,
+	})
+
+	return created, nil
+}
+
+func (s *menuService) SetPriceOverride(ctx context.Context, restaurantID uuid.UUID, articleID int, price float64) (*dto.ArticlePriceOverride, error) {
+	inherited, err := s.checkRestaurantArticle(ctx, restaurantID, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !inherited {
+		return nil, ErrArticleNotInherited
+	}
+
+	var before *dto.ArticlePriceOverride
+	dbPreviousOverride, err := s.db.Queries.GetArticlePriceOverride(ctx, repository.GetArticlePriceOverrideParams{
+		ArticleID:    int32(articleID),
+		RestaurantID: restaurantID,
+	})
+	if err == nil {
+		before = dto.NewArticlePriceOverride(&dbPreviousOverride)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error fetching price override of article %d for restaurant ID %s: %w", articleID, restaurantID, err)
+	}
+
+	dbOverride, err := s.db.Queries.UpsertArticlePriceOverride(ctx, repository.UpsertArticlePriceOverrideParams{
+		ArticleID:    int32(articleID),
+		RestaurantID: restaurantID,
+		Price:        price,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error setting price override of article %d for restaurant ID %s: %w", articleID, restaurantID, err)
+	}
+
+	override := dto.NewArticlePriceOverride(&dbOverride)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityArticle,
+		EntityID:     strconv.Itoa(articleID),
+		Before:       before,
+		After:        override,
+	})
+
+	return override, nil
+}
+
+func (s *menuService) DeletePriceOverride(ctx context.Context, restaurantID uuid.UUID, articleID int) error {
+	dbOverride, err := s.db.Queries.DeleteArticlePriceOverride(ctx, repository.DeleteArticlePriceOverrideParams{
+		ArticleID:    int32(articleID),
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrArticlePriceOverrideUnset
+		}
+		return fmt.Errorf("error deleting price override of article %d for restaurant ID %s: %w", articleID, restaurantID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityArticle,
+		EntityID:     strconv.Itoa(articleID),
+		Before:       dto.NewArticlePriceOverride(&dbOverride),
+	})
+
+	return nil
+}
+
+// SetAvailability marks the article in or out of stock for the restaurant only, shared menus stay untouched.
+func (s *menuService) SetAvailability(ctx context.Context, restaurantID uuid.UUID, articleID int, isAvailable bool) error {
+	if _, err := s.checkRestaurantArticle(ctx, restaurantID, articleID); err != nil {
+		return err
+	}
+
+	var err error
+	if isAvailable {
+		err = s.db.Queries.DeleteArticleUnavailability(ctx, repository.DeleteArticleUnavailabilityParams{
+			ArticleID:    int32(articleID),
+			RestaurantID: restaurantID,
+		})
+	} else {
+		err = s.db.Queries.SetArticleUnavailable(ctx, repository.SetArticleUnavailableParams{
+			ArticleID:    int32(articleID),
+			RestaurantID: restaurantID,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error setting availability of article %d for restaurant ID %s: %w", articleID, restaurantID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityArticle,
+		EntityID:     strconv.Itoa(articleID),
+		After:        articleAvailabilityAuditData{ArticleID: articleID, IsAvailable: isAvailable},
+	})
+
+	return nil
+}
+
+// checkRestaurantArticle ensures the article belongs to a menu of the restaurant or to a menu shared with it
+// by its organization, it reports whether the article is inherited.
+func (s *menuService) checkRestaurantArticle(ctx context.Context, restaurantID uuid.UUID, articleID int) (bool, error) {
+	owner, err := s.db.Queries.GetArticleMenuOwner(ctx, int32(articleID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrArticleNotFound
+		}
+		return false, fmt.Errorf("error fetching menu owner of article %d: %w", articleID, err)
+	}
+
+	if owner.RestaurantID != nil {
+		if *owner.RestaurantID == restaurantID {
+			return false, nil
+		}
+		return false, ErrArticleNotFound
+	}
+
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return false, fmt.Errorf("error fetching restaurant by ID %s: %w", restaurantID, err)
+	}
+	if dbRestaurant.OrganizationID == nil || owner.OrganizationID == nil || *dbRestaurant.OrganizationID != *owner.OrganizationID {
+		return false, ErrArticleNotFound
+	}
+
+	return true, nil
 }
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/cache"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+var (
+	ErrOIDCDisabled         = errors.New("sign in with Google is not configured")
+	ErrOIDCUnavailable      = errors.New("identity provider is unavailable")
+	ErrOIDCInvalidState     = errors.New("invalid or expired sign in attempt, please try again")
+	ErrOIDCInvalidIDToken   = errors.New("invalid ID token")
+	ErrOIDCEmailNotVerified = errors.New("the email of the Google account is not verified")
+)
+
+// jwksRefreshInterval bounds how often the signing keys are fetched again, unknown key IDs trigger a refresh.
+const jwksRefreshInterval = time.Hour
+
+// OIDCService runs the authorization code flow with PKCE against the configured OpenID Connect issuer.
+type OIDCService interface {
+	AuthorizationURL(ctx context.Context) (authorizationURL, state string, err error)
+	Exchange(ctx context.Context, code, state string) (*dto.OIDCIdentity, error)
+}
+
+type oidcService struct {
+	cfg    *config.OIDC
+	cache  cache.Cache
+	client *http.Client
+
+	mu            sync.Mutex
+	jwks          map[string]*rsa.PublicKey
+	jwksFetchedAt time.Time
+}
+
+func NewOIDCService(cfg *config.OIDC, cache cache.Cache) *oidcService {
+	return &oidcService{
+		cfg:   cfg,
+		cache: cache,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// oidcState is kept in cache between the redirection to the issuer and the callback.
+type oidcState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+type idTokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Audience      json.RawMessage `json:"aud"`
+	Subject       string          `json:"sub"`
+	ExpiresAt     int64           `json:"exp"`
+	Nonce         string          `json:"nonce"`
+	Email         string          `json:"email"`
+	EmailVerified bool            `json:"email_verified"`
+	Name          string          `json:"name"`
+	Picture       string          `json:"picture"`
+}
+
+// AuthorizationURL also returns the state, the caller binds it to the client starting the flow.
+func (s *oidcService) AuthorizationURL(ctx context.Context) (string, string, error) {
+	if s.cfg.ClientID == "" {
+		return "", "", ErrOIDCDisabled
+	}
+
+	state, err := security.GenerateRandomString(24)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := security.GenerateRandomString(24)
+	if err != nil {
+		return "", "", err
+	}
+	// 48 bytes encode to 64 characters without padding, within the 43-128 range required by PKCE
+	codeVerifier, err := security.GenerateRandomString(48)
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(oidcState{
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error marshaling OIDC state: %w", err)
+	}
+
+	err = s.cache.Set(ctx, cache.GenerateKey(string(keys.OIDCState), state), data, keys.OIDCStateDuration)
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	params := url.Values{}
+	params.Set("client_id", s.cfg.ClientID)
+	params.Set("redirect_uri", s.cfg.RedirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", "openid email profile")
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	return fmt.Sprintf("%s?%s", s.cfg.AuthURL, params.Encode()), state, nil
+}
+
+// Exchange trades the authorization code for an ID token and returns the identity it describes.
+// The state is single use, replaying a callback fails.
+func (s *oidcService) Exchange(ctx context.Context, code, state string) (*dto.OIDCIdentity, error) {
+	if s.cfg.ClientID == "" {
+		return nil, ErrOIDCDisabled
+	}
+
+	stateKey := cache.GenerateKey(string(keys.OIDCState), state)
+	data, err := s.cache.Get(ctx, stateKey)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return nil, ErrOIDCInvalidState
+		}
+		return nil, err
+	}
+	if err := s.cache.Delete(ctx, stateKey); err != nil {
+		return nil, err
+	}
+
+	var storedState oidcState
+	if err := json.Unmarshal(data, &storedState); err != nil {
+		return nil, fmt.Errorf("error unmarshaling OIDC state: %w", err)
+	}
+
+	idToken, err := s.exchangeCode(ctx, code, storedState.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.verifyIDToken(ctx, idToken, storedState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.EmailVerified || claims.Email == "" {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	identity := &dto.OIDCIdentity{
+		Provider:      enum.IdentityProviderGoogle,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}
+	if claims.Picture != "" {
+		identity.AvatarURL = &claims.Picture
+	}
+
+	return identity, nil
+}
+
+func (s *oidcService) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.cfg.RedirectURL)
+	form.Set("client_id", s.cfg.ClientID)
+	form.Set("client_secret", s.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrOIDCUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	// The issuer answers 400 for expired or already used codes
+	if resp.StatusCode == http.StatusBadRequest {
+		return "", ErrOIDCInvalidState
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token endpoint returned status: %s", ErrOIDCUnavailable, resp.Status)
+	}
+
+	var result struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding token endpoint response: %w", err)
+	}
+	if result.IDToken == "" {
+		return "", ErrOIDCInvalidIDToken
+	}
+
+	return result.IDToken, nil
+}
+
+// verifyIDToken checks the RS256 signature against the issuer keys, then the issuer, audience, expiry and nonce.
+func (s *oidcService) verifyIDToken(ctx context.Context, idToken, nonce string) (*idTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrOIDCInvalidIDToken
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil || header.Algorithm != "RS256" {
+		return nil, ErrOIDCInvalidIDToken
+	}
+
+	key, err := s.getSigningKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, ErrOIDCInvalidIDToken
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	var claims idTokenClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, ErrOIDCInvalidIDToken
+	}
+
+	if claims.Issuer != s.cfg.Issuer || claims.Subject == "" {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	if !hasAudience(claims.Audience, s.cfg.ClientID) {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, ErrOIDCInvalidIDToken
+	}
+
+	return &claims, nil
+}
+
+// hasAudience accepts both forms of the aud claim, a single string or an array of strings.
+func hasAudience(audience json.RawMessage, clientID string) bool {
+	var single string
+	if err := json.Unmarshal(audience, &single); err == nil {
+		return single == clientID
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(audience, &multiple); err == nil {
+		return slices.Contains(multiple, clientID)
+	}
+
+	return false
+}
+
+func (s *oidcService) getSigningKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.jwks[keyID]
+	if ok && time.Since(s.jwksFetchedAt) < jwksRefreshInterval {
+		return key, nil
+	}
+
+	jwks, err := s.fetchJWKS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.jwks = jwks
+	s.jwksFetchedAt = time.Now()
+
+	key, ok = s.jwks[keyID]
+	if !ok {
+		return nil, ErrOIDCInvalidIDToken
+	}
+	return key, nil
+}
+
+func (s *oidcService) fetchJWKS(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: JWKS endpoint returned status: %s", ErrOIDCUnavailable, resp.Status)
+	}
+
+	var result struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding JWKS response: %w", err)
+	}
+
+	jwks := make(map[string]*rsa.PublicKey, len(result.Keys))
+	for _, jwk := range result.Keys {
+		if jwk.KeyType != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		jwks[jwk.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return jwks, nil
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+)
+
+// openingStatusHorizonDays is how far ahead we look for the next opening.
+const openingStatusHorizonDays = 14
+
+var (
+	ErrInvalidTimezone              = errors.New("invalid timezone")
+	ErrOpeningHourExceptionNotFound = errors.New("opening hour exception not found")
+)
+
+type OpeningHoursService interface {
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.OpeningHours, error)
+	Update(ctx context.Context, restaurantID uuid.UUID, openingHours *dto.UpdateOpeningHours) (*dto.OpeningHours, error)
+	CreateException(ctx context.Context, restaurantID uuid.UUID, exception *dto.CreateOpeningHourException) (*dto.OpeningHourException, error)
+	DeleteException(ctx context.Context, restaurantID uuid.UUID, exceptionID int) error
+	SetOpeningStatus(ctx context.Context, restaurant *dto.Restaurant, now time.Time) error
+}
+
+type openingHoursService struct {
+	db       *database.DB
+	auditSvc AuditService
+}
+
+func NewOpeningHoursService(db *database.DB, auditSvc AuditService) *openingHoursService {
+	return &openingHoursService{
+		db:       db,
+		auditSvc: auditSvc,
+	}
+}
+
+func (s *openingHoursService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.OpeningHours, error) {
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", restaurantID, err)
+	}
+
+	loc, err := time.LoadLocation(dbRestaurant.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("error loading timezone %s for restaurant ID %s: %w", dbRestaurant.Timezone, restaurantID, err)
+	}
+
+	weekly, exceptions, err := s.fetch(ctx, restaurantID, startOfDay(time.Now(), loc))
+	if err != nil {
+		return nil, err
+	}
+
+	return newOpeningHours(dbRestaurant.Timezone, weekly, exceptions), nil
+}
+
+func (s *openingHoursService) Update(ctx context.Context, restaurantID uuid.UUID, openingHours *dto.UpdateOpeningHours) (*dto.OpeningHours, error) {
+	if _, err := time.LoadLocation(openingHours.Timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	before, err := s.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	err = qtx.UpdateRestaurantTimezone(ctx, repository.UpdateRestaurantTimezoneParams{
+		Timezone: openingHours.Timezone,
+		ID:       restaurantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating timezone for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	if err := qtx.DeleteOpeningHoursByRestaurantID(ctx, restaurantID); err != nil {
+		return nil, fmt.Errorf("error deleting opening hours for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	for day, ranges := range openingHours.Weekly {
+		for _, r := range ranges {
+			_, err := qtx.CreateOpeningHour(ctx, repository.CreateOpeningHourParams{
+				RestaurantID: restaurantID,
+				DayOfWeek:    int16(day),
+				OpensAt:      r.OpensAt,
+				ClosesAt:     r.ClosesAt,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error creating opening hour for restaurant ID %s: %w", restaurantID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityOpeningHours,
+		EntityID:     restaurantID.String(),
+		Before:       before,
+		After:        updated,
+	})
+
+	return updated, nil
+}
+
+func (s *openingHoursService) CreateException(ctx context.Context, restaurantID uuid.UUID, exception *dto.CreateOpeningHourException) (*dto.OpeningHourException, error) {
+	params := repository.CreateOpeningHourExceptionParams{
+		RestaurantID: restaurantID,
+		Date:         exception.Date,
+		Reason:       exception.Reason,
+	}
+	if exception.Range != nil {
+		params.OpensAt = &exception.Range.OpensAt
+		params.ClosesAt = &exception.Range.ClosesAt
+	}
+
+	dbException, err := s.db.Queries.CreateOpeningHourException(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating opening hour exception for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	created := dto.NewOpeningHourException(&dbException)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityOpeningHourException,
+		EntityID:     strconv.Itoa(int(dbException.ID)),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+func (s *openingHoursService) DeleteException(ctx context.Context, restaurantID uuid.UUID, exceptionID int) error {
+	dbException, err := s.db.Queries.DeleteOpeningHourException(ctx, repository.DeleteOpeningHourExceptionParams{
+		ID:           int32(exceptionID),
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOpeningHourExceptionNotFound
+		}
+		return fmt.Errorf("error deleting opening hour exception %d for restaurant ID %s: %w", exceptionID, restaurantID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionDelete,
+		EntityType:   enum.AuditEntityOpeningHourException,
+		EntityID:     strconv.Itoa(exceptionID),
+		Before:       dto.NewOpeningHourException(&dbException),
+	})
+
+	return nil
+}
+
+// SetOpeningStatus fills the opening hours, is_open_now and next_change_at fields of the restaurant.
+func (s *openingHoursService) SetOpeningStatus(ctx context.Context, restaurant *dto.Restaurant, now time.Time) error {
+	loc, err := time.LoadLocation(restaurant.Timezone)
+	if err != nil {
+		return fmt.Errorf("error loading timezone %s for restaurant ID %s: %w", restaurant.Timezone, restaurant.ID, err)
+	}
+
+	today := startOfDay(now, loc)
+	// Exceptions from yesterday are needed for overnight ranges still running today.
+	weekly, exceptions, err := s.fetch(ctx, restaurant.ID, today.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	intervals := openingIntervals(weekly, exceptions, today, openingStatusHorizonDays)
+	isOpen, nextChangeAt := openingStatusAt(intervals, now)
+
+	restaurant.IsOpenNow = &isOpen
+	restaurant.NextChangeAt = nextChangeAt
+	restaurant.OpeningHours = newOpeningHours(restaurant.Timezone, weekly, upcomingExceptions(exceptions, today))
+
+	return nil
+}
+
+func (s *openingHoursService) fetch(ctx context.Context, restaurantID uuid.UUID, from time.Time) ([]repository.RestaurantOpeningHour, []repository.RestaurantOpeningHourException, error) {
+	weekly, err := s.db.Queries.GetOpeningHoursByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching opening hours for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	// DATE columns are compared against a date without timezone
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	exceptions, err := s.db.Queries.GetOpeningHourExceptionsByRestaurantID(ctx, repository.GetOpeningHourExceptionsByRestaurantIDParams{
+		RestaurantID: restaurantID,
+		Date:         fromDate,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching opening hour exceptions for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	return weekly, exceptions, nil
+}
+
+func newOpeningHours(timezone string, weekly []repository.RestaurantOpeningHour, exceptions []repository.RestaurantOpeningHourException) *dto.OpeningHours {
+	openingHours := &dto.OpeningHours{
+		Timezone:   timezone,
+		Weekly:     make([]dto.OpeningHour, len(weekly)),
+		Exceptions: make([]dto.OpeningHourException, len(exceptions)),
+	}
+	for i := range weekly {
+		openingHours.Weekly[i] = *dto.NewOpeningHour(&weekly[i])
+	}
+	for i := range exceptions {
+		openingHours.Exceptions[i] = *dto.NewOpeningHourException(&exceptions[i])
+	}
+
+	return openingHours
+}
+
+func upcomingExceptions(exceptions []repository.RestaurantOpeningHourException, today time.Time) []repository.RestaurantOpeningHourException {
+	todayKey := today.Format(dto.DateLayout)
+	upcoming := make([]repository.RestaurantOpeningHourException, 0, len(exceptions))
+	for _, e := range exceptions {
+		if e.Date.Format(dto.DateLayout) >= todayKey {
+			upcoming = append(upcoming, e)
+		}
+	}
+
+	return upcoming
+}
+
+type openingInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// openingIntervals expands the weekly hours and exceptions into concrete, merged
+// intervals from the day before today up to the given number of days ahead.
+func openingIntervals(weekly []repository.RestaurantOpeningHour, exceptions []repository.RestaurantOpeningHourException, today time.Time, days int) []openingInterval {
+	exceptionsByDate := make(map[string][]repository.RestaurantOpeningHourException)
+	for _, e := range exceptions {
+		key := e.Date.Format(dto.DateLayout)
+		exceptionsByDate[key] = append(exceptionsByDate[key], e)
+	}
+
+	var intervals []openingInterval
+	for offset := -1; offset <= days; offset++ {
+		day := today.AddDate(0, 0, offset)
+
+		var ranges []dto.TimeRange
+		if dayExceptions, ok := exceptionsByDate[day.Format(dto.DateLayout)]; ok {
+			for _, e := range dayExceptions {
+				if e.OpensAt != nil && e.ClosesAt != nil {
+					ranges = append(ranges, dto.TimeRange{OpensAt: *e.OpensAt, ClosesAt: *e.ClosesAt})
+				}
+			}
+		} else {
+			for _, h := range weekly {
+				if time.Weekday(h.DayOfWeek) == day.Weekday() {
+					ranges = append(ranges, dto.TimeRange{OpensAt: h.OpensAt, ClosesAt: h.ClosesAt})
+				}
+			}
+		}
+
+		for _, r := range ranges {
+			closingDay := day.Day()
+			if r.ClosesAt <= r.OpensAt {
+				closingDay++
+			}
+			intervals = append(intervals, openingInterval{
+				start: time.Date(day.Year(), day.Month(), day.Day(), 0, int(r.OpensAt), 0, 0, day.Location()),
+				end:   time.Date(day.Year(), day.Month(), closingDay, 0, int(r.ClosesAt), 0, 0, day.Location()),
+			})
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	merged := make([]openingInterval, 0, len(intervals))
+	for _, interval := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !interval.start.After(merged[last].end) {
+			if interval.end.After(merged[last].end) {
+				merged[last].end = interval.end
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+
+	return merged
+}
+
+// openingStatusAt expects sorted and merged intervals.
+func openingStatusAt(intervals []openingInterval, now time.Time) (bool, *time.Time) {
+	for _, interval := range intervals {
+		if !now.Before(interval.start) && now.Before(interval.end) {
+			end := interval.end
+			return true, &end
+		}
+		if interval.start.After(now) {
+			start := interval.start
+			return false, &start
+		}
+	}
+
+	return false, nil
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
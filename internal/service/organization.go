@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+)
+
+var (
+	ErrOrganizationMemberNotFound     = errors.New("organization member not found")
+	ErrOrganizationMemberExists       = errors.New("user is already a member of the organization")
+	ErrOrganizationLastOwner          = errors.New("the last owner of the organization cannot be removed")
+	ErrRestaurantInOtherOrganization  = errors.New("restaurant already belongs to an organization")
+	ErrRestaurantNotInOrganization    = errors.New("restaurant does not belong to the organization")
+	ErrRestaurantOwnershipRequired    = errors.New("only the restaurant owner can add it to an organization")
+	ErrOrganizationInviteeNotVerified = errors.New("user not found or email not verified")
+)
+
+type OrganizationService interface {
+	Create(ctx context.Context, name string, userID uuid.UUID) (*dto.Organization, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.Organization, error)
+	GetUserRoleID(ctx context.Context, organizationID, userID uuid.UUID) (int, error)
+	GetRestaurants(ctx context.Context, organizationID uuid.UUID) ([]*dto.Restaurant, error)
+	AddRestaurant(ctx context.Context, organizationID, restaurantID, userID uuid.UUID) error
+	RemoveRestaurant(ctx context.Context, organizationID, restaurantID uuid.UUID) error
+	GetMembers(ctx context.Context, organizationID uuid.UUID) ([]*dto.OrganizationMember, error)
+	AddMember(ctx context.Context, organizationID uuid.UUID, email string, roleID enum.RoleID) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+}
+
+type organizationService struct {
+	db       *database.DB
+	auditSvc AuditService
+}
+
+func NewOrganizationService(db *database.DB, auditSvc AuditService) *organizationService {
+	return &organizationService{
+		db:       db,
+		auditSvc: auditSvc,
+	}
+}
+
+// organizationAuditData is recorded on the restaurants joining or leaving an organization.
+type organizationAuditData struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+func (s *organizationService) Create(ctx context.Context, name string, userID uuid.UUID) (*dto.Organization, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	organization, err := qtx.CreateOrganization(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("error creating organization: %w", err)
+	}
+
+	err = qtx.AddOrganizationUser(ctx, repository.AddOrganizationUserParams{
+		OrganizationID: organization.ID,
+		UserID:         userID,
+		RoleID:         int32(enum.RoleOwner),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding organization user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return dto.NewOrganization(&organization), nil
+}
+
+func (s *organizationService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.Organization, error) {
+	dbOrganizations, err := s.db.Queries.GetOrganizationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching organizations for user ID %s: %w", userID, err)
+	}
+
+	organizations := make([]*dto.Organization, len(dbOrganizations))
+	for i := range dbOrganizations {
+		organizations[i] = dto.NewOrganization(&dbOrganizations[i])
+	}
+	return organizations, nil
+}
+
+func (s *organizationService) GetUserRoleID(ctx context.Context, organizationID, userID uuid.UUID) (int, error) {
+	roleID, err := s.db.Queries.GetOrganizationUserRoleID(ctx, repository.GetOrganizationUserRoleIDParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrOrganizationMemberNotFound
+		}
+		return 0, fmt.Errorf("error fetching role of user ID %s for organization ID %s: %w", userID, organizationID, err)
+	}
+
+	return int(roleID), nil
+}
+
+func (s *organizationService) GetRestaurants(ctx context.Context, organizationID uuid.UUID) ([]*dto.Restaurant, error) {
+	dbRestaurants, err := s.db.Queries.GetRestaurantsByOrganizationID(ctx, &organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurants for organization ID %s: %w", organizationID, err)
+	}
+
+	restaurants := make([]*dto.Restaurant, len(dbRestaurants))
+	for i := range dbRestaurants {
+		restaurants[i] = dto.NewRestaurant(&dbRestaurants[i])
+	}
+	return restaurants, nil
+}
+
+// AddRestaurant moves a restaurant owned by the user into the organization, its members keep their direct roles.
+func (s *organizationService) AddRestaurant(ctx context.Context, organizationID, restaurantID, userID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	// Concurrent additions of the restaurant to organizations are checked one after the other
+	if err := qtx.LockRestaurant(ctx, restaurantID); err != nil {
+		return fmt.Errorf("error locking restaurant ID %s: %w", restaurantID, err)
+	}
+
+	roleID, err := qtx.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRestaurantOwnershipRequired
+		}
+		return fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", userID, restaurantID, err)
+	}
+	if enum.RoleID(roleID) != enum.RoleOwner {
+		return ErrRestaurantOwnershipRequired
+	}
+
+	dbRestaurant, err := qtx.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return fmt.Errorf("error fetching restaurant by ID %s: %w", restaurantID, err)
+	}
+	if dbRestaurant.OrganizationID != nil {
+		return ErrRestaurantInOtherOrganization
+	}
+
+	err = qtx.SetRestaurantOrganization(ctx, repository.SetRestaurantOrganizationParams{
+		OrganizationID: &organizationID,
+		ID:             restaurantID,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding restaurant ID %s to organization ID %s: %w", restaurantID, organizationID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &userID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityOrganization,
+		EntityID:     organizationID.String(),
+		After:        organizationAuditData{OrganizationID: organizationID},
+	})
+
+	return nil
+}
+
+func (s *organizationService) RemoveRestaurant(ctx context.Context, organizationID, restaurantID uuid.UUID) error {
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRestaurantNotInOrganization
+		}
+		return fmt.Errorf("error fetching restaurant by ID %s: %w", restaurantID, err)
+	}
+	if dbRestaurant.OrganizationID == nil || *dbRestaurant.OrganizationID != organizationID {
+		return ErrRestaurantNotInOrganization
+	}
+
+	err = s.db.Queries.SetRestaurantOrganization(ctx, repository.SetRestaurantOrganizationParams{
+		OrganizationID: nil,
+		ID:             restaurantID,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing restaurant ID %s from organization ID %s: %w", restaurantID, organizationID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionDelete,
+		EntityType:   enum.AuditEntityOrganization,
+		EntityID:     organizationID.String(),
+		Before:       organizationAuditData{OrganizationID: organizationID},
+	})
+
+	return nil
+}
+
+func (s *organizationService) GetMembers(ctx context.Context, organizationID uuid.UUID) ([]*dto.OrganizationMember, error) {
+	dbMembers, err := s.db.Queries.GetOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching members for organization ID %s: %w", organizationID, err)
+	}
+
+	members := make([]*dto.OrganizationMember, len(dbMembers))
+	for i := range dbMembers {
+		members[i] = dto.NewOrganizationMember(&dbMembers[i])
+	}
+	return members, nil
+}
+
+func (s *organizationService) AddMember(ctx context.Context, organizationID uuid.UUID, email string, roleID enum.RoleID) error {
+	dbUser, err := s.db.Queries.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrganizationInviteeNotVerified
+		}
+		return fmt.Errorf("error fetching user by email: %w", err)
+	}
+	if !dbUser.IsEmailVerified {
+		return ErrOrganizationInviteeNotVerified
+	}
+
+	_, err = s.db.Queries.GetOrganizationUserRoleID(ctx, repository.GetOrganizationUserRoleIDParams{
+		OrganizationID: organizationID,
+		UserID:         dbUser.ID,
+	})
+	if err == nil {
+		return ErrOrganizationMemberExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error fetching role of user ID %s for organization ID %s: %w", dbUser.ID, organizationID, err)
+	}
+
+	err = s.db.Queries.AddOrganizationUser(ctx, repository.AddOrganizationUserParams{
+		OrganizationID: organizationID,
+		UserID:         dbUser.ID,
+		RoleID:         int32(roleID),
+	})
+	if err != nil {
+		return fmt.Errorf("error adding user ID %s to organization ID %s: %w", dbUser.ID, organizationID, err)
+	}
+
+	// Organization members inherit their role on every restaurant of the organization
+	s.auditSvc.RecordForOrganization(ctx, organizationID, &dto.CreateAuditLog{
+		Action:     enum.AuditActionCreate,
+		EntityType: enum.AuditEntityOrganizationMember,
+		EntityID:   dbUser.ID.String(),
+		After:      memberAuditData{UserID: dbUser.ID, RoleID: roleID},
+	})
+
+	return nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	// Owners removing each other at the same time are counted one after the other
+	if err := qtx.LockOrganization(ctx, organizationID); err != nil {
+		return fmt.Errorf("error locking organization ID %s: %w", organizationID, err)
+	}
+
+	roleID, err := qtx.GetOrganizationUserRoleID(ctx, repository.GetOrganizationUserRoleIDParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrganizationMemberNotFound
+		}
+		return fmt.Errorf("error fetching role of user ID %s for organization ID %s: %w", userID, organizationID, err)
+	}
+
+	if enum.RoleID(roleID) == enum.RoleOwner {
+		owners, err := qtx.CountOrganizationUsersByRoleID(ctx, repository.CountOrganizationUsersByRoleIDParams{
+			OrganizationID: organizationID,
+			RoleID:         int32(enum.RoleOwner),
+		})
+		if err != nil {
+			return fmt.Errorf("error counting owners of organization ID %s: %w", organizationID, err)
+		}
+		if owners <= 1 {
+			return ErrOrganizationLastOwner
+		}
+	}
+
+	_, err = qtx.DeleteOrganizationUser(ctx, repository.DeleteOrganizationUserParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing user ID %s from organization ID %s: %w", userID, organizationID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	s.auditSvc.RecordForOrganization(ctx, organizationID, &dto.CreateAuditLog{
+		Action:     enum.AuditActionDelete,
+		EntityType: enum.AuditEntityOrganizationMember,
+		EntityID:   userID.String(),
+		Before:     memberAuditData{UserID: userID, RoleID: enum.RoleID(roleID)},
+	})
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+var (
+	ErrOwnershipTransferInProgress = errors.New("an ownership transfer is already in progress for this restaurant")
+	ErrOwnershipTransferNotFound   = errors.New("ownership transfer not found")
+	ErrOwnershipTransferToSelf     = errors.New("cannot transfer the ownership to yourself")
+	ErrNomineeNotMember            = errors.New("nominee is not a member of the restaurant")
+	ErrNominatorNotOwner           = errors.New("only an owner of the restaurant can transfer its ownership")
+)
+
+type OwnershipTransferService interface {
+	Nominate(ctx context.Context, restaurantID, ownerID, nomineeID uuid.UUID) (*dto.OwnershipTransfer, error)
+	GetPendingByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.OwnershipTransfer, error)
+	Confirm(ctx context.Context, token string) (*dto.OwnershipTransfer, error)
+	Cancel(ctx context.Context, restaurantID uuid.UUID) error
+}
+
+type ownershipTransferService struct {
+	cfg       *config.App
+	db        *database.DB
+	auditSvc  AuditService
+	mailerSvc MailerService
+	tokenSvc  TokenService
+}
+
+func NewOwnershipTransferService(cfg *config.App, db *database.DB, auditSvc AuditService, tokenSvc TokenService, mailerSvc MailerService) *ownershipTransferService {
+	return &ownershipTransferService{
+		cfg:       cfg,
+		db:        db,
+		auditSvc:  auditSvc,
+		mailerSvc: mailerSvc,
+		tokenSvc:  tokenSvc,
+	}
+}
+
+func (s *ownershipTransferService) Nominate(ctx context.Context, restaurantID, ownerID, nomineeID uuid.UUID) (*dto.OwnershipTransfer, error) {
+	if ownerID == nomineeID {
+		return nil, ErrOwnershipTransferToSelf
+	}
+
+	// The transfer swaps the roles of both members, the nominator must hold the owner role directly
+	// and not through an organization or a custom role
+	ownerRoleID, err := s.db.Queries.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: restaurantID,
+		UserID:       ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNominatorNotOwner
+		}
+		return nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", ownerID, restaurantID, err)
+	}
+	if enum.RoleID(ownerRoleID) != enum.RoleOwner {
+		return nil, ErrNominatorNotOwner
+	}
+
+	_, err = s.db.Queries.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: restaurantID,
+		UserID:       nomineeID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNomineeNotMember
+		}
+		return nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", nomineeID, restaurantID, err)
+	}
+
+	_, err = s.db.Queries.GetPendingOwnershipTransferByRestaurantID(ctx, restaurantID)
+	if err == nil {
+		return nil, ErrOwnershipTransferInProgress
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error fetching pending ownership transfer for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	dbTransfer, err := s.db.Queries.CreateOwnershipTransfer(ctx, repository.CreateOwnershipTransferParams{
+		RestaurantID: restaurantID,
+		FromUserID:   &ownerID,
+		ToUserID:     &nomineeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating ownership transfer for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	if err := s.sendNomination(ctx, &dbTransfer); err != nil {
+		// The nominee never heard of the transfer, it must not block the next nomination
+		if err := s.db.Queries.CancelOwnershipTransfer(ctx, dbTransfer.ID); err != nil {
+			log.Printf("error canceling ownership transfer %d after a failed nomination email: %v", dbTransfer.ID, err)
+		}
+		return nil, err
+	}
+
+	created := dto.NewOwnershipTransfer(&dbTransfer)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &ownerID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityOwnershipTransfer,
+		EntityID:     strconv.Itoa(int(dbTransfer.ID)),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+func (s *ownershipTransferService) GetPendingByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.OwnershipTransfer, error) {
+	dbTransfer, err := s.db.Queries.GetPendingOwnershipTransferByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		return nil, fmt.Errorf("error fetching pending ownership transfer for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	return dto.NewOwnershipTransfer(&dbTransfer), nil
+}
+
+func (s *ownershipTransferService) Confirm(ctx context.Context, token string) (*dto.OwnershipTransfer, error) {
+	transferIDStr, err := s.tokenSvc.VerifySPT(ctx, keys.OwnershipTransfer, token)
+	if err != nil {
+		return nil, err
+	}
+
+	transferID, err := strconv.Atoi(transferIDStr)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbTransfer, err := qtx.GetOwnershipTransferByID(ctx, int32(transferID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		return nil, fmt.Errorf("error fetching ownership transfer %d: %w", transferID, err)
+	}
+	// A transfer is left without its participant once their account has been deleted
+	if dbTransfer.Status != string(enum.OwnershipTransferStatusPending) || dbTransfer.FromUserID == nil || dbTransfer.ToUserID == nil {
+		return nil, ErrOwnershipTransferNotFound
+	}
+
+	// Both members must still be in place with the expected roles
+	ownerRoleID, err := qtx.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: dbTransfer.RestaurantID,
+		UserID:       *dbTransfer.FromUserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOwnershipTransferNotFound
+		}
+		return nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", *dbTransfer.FromUserID, dbTransfer.RestaurantID, err)
+	}
+	if enum.RoleID(ownerRoleID) != enum.RoleOwner {
+		return nil, ErrOwnershipTransferNotFound
+	}
+
+	nomineeRoleID, err := qtx.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: dbTransfer.RestaurantID,
+		UserID:       *dbTransfer.ToUserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNomineeNotMember
+		}
+		return nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", *dbTransfer.ToUserID, dbTransfer.RestaurantID, err)
+	}
+
+	err = qtx.UpdateRestaurantUserRole(ctx, repository.UpdateRestaurantUserRoleParams{
+		RoleID:       int32(enum.RoleOwner),
+		RestaurantID: dbTransfer.RestaurantID,
+		UserID:       *dbTransfer.ToUserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error promoting user ID %s for restaurant ID %s: %w", *dbTransfer.ToUserID, dbTransfer.RestaurantID, err)
+	}
+
+	err = qtx.UpdateRestaurantUserRole(ctx, repository.UpdateRestaurantUserRoleParams{
+		RoleID:       nomineeRoleID,
+		RestaurantID: dbTransfer.RestaurantID,
+		UserID:       *dbTransfer.FromUserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error demoting user ID %s for restaurant ID %s: %w", *dbTransfer.FromUserID, dbTransfer.RestaurantID, err)
+	}
+
+	completedTransfer, err := qtx.CompleteOwnershipTransfer(ctx, repository.CompleteOwnershipTransferParams{
+		FromUserNewRoleID: &nomineeRoleID,
+		ID:                dbTransfer.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error completing ownership transfer %d: %w", transferID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenSvc.RevokeSPT(ctx, keys.OwnershipTransfer, transferIDStr); err != nil {
+		return nil, err
+	}
+
+	completed := dto.NewOwnershipTransfer(&completedTransfer)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: completedTransfer.RestaurantID,
+		ActorUserID:  completedTransfer.ToUserID,
+		Action:       enum.AuditActionConfirm,
+		EntityType:   enum.AuditEntityOwnershipTransfer,
+		EntityID:     strconv.Itoa(int(completedTransfer.ID)),
+		Before:       dto.NewOwnershipTransfer(&dbTransfer),
+		After:        completed,
+	})
+
+	s.notifyPreviousOwner(ctx, &completedTransfer)
+
+	return completed, nil
+}
+
+func (s *ownershipTransferService) Cancel(ctx context.Context, restaurantID uuid.UUID) error {
+	dbTransfer, err := s.db.Queries.GetPendingOwnershipTransferByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOwnershipTransferNotFound
+		}
+		return fmt.Errorf("error fetching pending ownership transfer for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	if err := s.db.Queries.CancelOwnershipTransfer(ctx, dbTransfer.ID); err != nil {
+		return fmt.Errorf("error canceling ownership transfer %d: %w", dbTransfer.ID, err)
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionCancel,
+		EntityType:   enum.AuditEntityOwnershipTransfer,
+		EntityID:     strconv.Itoa(int(dbTransfer.ID)),
+		Before:       dto.NewOwnershipTransfer(&dbTransfer),
+	})
+
+	return s.tokenSvc.RevokeSPT(ctx, keys.OwnershipTransfer, strconv.Itoa(int(dbTransfer.ID)))
+}
+
+func (s *ownershipTransferService) sendNomination(ctx context.Context, transfer *repository.RestaurantOwnershipTransfer) error {
+	spt, err := s.tokenSvc.GenerateSPT(ctx, keys.OwnershipTransfer, strconv.Itoa(int(transfer.ID)), keys.OwnershipTransferTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	restaurant, owner, nominee, err := s.loadParticipants(ctx, transfer)
+	if err != nil {
+		return err
+	}
+
+	emailtmpl, err := s.mailerSvc.RenderTemplate("ownership_transfer.tmpl", map[string]any{
+		"Host":       s.cfg.Host,
+		"User":       nominee,
+		"Owner":      owner,
+		"Restaurant": restaurant,
+		"Token":      spt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{nominee.Email},
+		Subject: "You have been nominated as restaurant owner",
+		Body:    emailtmpl,
+	})
+}
+
+func (s *ownershipTransferService) notifyPreviousOwner(ctx context.Context, transfer *repository.RestaurantOwnershipTransfer) {
+	restaurant, previousOwner, newOwner, err := s.loadParticipants(ctx, transfer)
+	if err == nil {
+		var emailtmpl string
+		emailtmpl, err = s.mailerSvc.RenderTemplate("ownership_transfer_completed.tmpl", map[string]any{
+			"User":       previousOwner,
+			"NewOwner":   newOwner,
+			"Restaurant": restaurant,
+		})
+		if err == nil {
+			err = s.mailerSvc.Send(&mailer.Mail{
+				To:      []string{previousOwner.Email},
+				Subject: "Ownership transfer completed",
+				Body:    emailtmpl,
+			})
+		}
+	}
+	if err != nil {
+		log.Printf("error notifying previous owner of ownership transfer %d: %v", transfer.ID, err)
+	}
+}
+
+func (s *ownershipTransferService) loadParticipants(ctx context.Context, transfer *repository.RestaurantOwnershipTransfer) (*dto.Restaurant, *dto.User, *dto.User, error) {
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, transfer.RestaurantID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error fetching restaurant by ID %s: %w", transfer.RestaurantID, err)
+	}
+
+	if transfer.FromUserID == nil || transfer.ToUserID == nil {
+		return nil, nil, nil, fmt.Errorf("ownership transfer %d has a deleted participant", transfer.ID)
+	}
+
+	dbFromUser, err := s.db.Queries.GetUserByID(ctx, *transfer.FromUserID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error fetching user by ID %s: %w", *transfer.FromUserID, err)
+	}
+
+	dbToUser, err := s.db.Queries.GetUserByID(ctx, *transfer.ToUserID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error fetching user by ID %s: %w", *transfer.ToUserID, err)
+	}
+
+	return dto.NewRestaurant(&dbRestaurant), dto.NewUser(&dbFromUser), dto.NewUser(&dbToUser), nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+)
+
+var ErrUnknownPermission = errors.New("unknown permission")
+
+// permissionCatalogue lists the permissions a role can be granted
+var permissionCatalogue = []enum.Permission{
+	enum.PermissionRestaurantRead,
+	enum.PermissionRestaurantWrite,
+	enum.PermissionRestaurantVerify,
+	enum.PermissionRestaurantTransfer,
+	enum.PermissionMenuRead,
+	enum.PermissionMenuWrite,
+	enum.PermissionMenuAvailability,
+	enum.PermissionTeamRead,
+	enum.PermissionTeamManage,
+	enum.PermissionRolesManage,
+	enum.PermissionReportsRead,
+	enum.PermissionAuditRead,
+	enum.PermissionAPIKeysManage,
+}
+
+type PermissionService interface {
+	GetCatalogue() []enum.Permission
+	HasPermission(ctx context.Context, roleID int32, permission enum.Permission) (bool, error)
+}
+
+type permissionService struct {
+	db *database.DB
+}
+
+func NewPermissionService(db *database.DB) *permissionService {
+	return &permissionService{
+		db: db,
+	}
+}
+
+func (s *permissionService) GetCatalogue() []enum.Permission {
+	return slices.Clone(permissionCatalogue)
+}
+
+func (s *permissionService) HasPermission(ctx context.Context, roleID int32, permission enum.Permission) (bool, error) {
+	allowed, err := s.db.Queries.RoleHasPermission(ctx, repository.RoleHasPermissionParams{
+		RoleID:     roleID,
+		Permission: string(permission),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error checking permission %s for role %d: %w", permission, roleID, err)
+	}
+
+	return allowed, nil
+}
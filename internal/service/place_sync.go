@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+)
+
+var (
+	ErrPlaceUpdateNotFound     = errors.New("place update not found")
+	ErrPlaceUpdateNotSuggested = errors.New("place update is not a pending suggestion")
+)
+
+// placeFields lists the restaurant fields compared with the places provider
+var placeFields = []enum.RestaurantField{
+	enum.RestaurantFieldName,
+	enum.RestaurantFieldAddress,
+	enum.RestaurantFieldPhone,
+	enum.RestaurantFieldLocation,
+}
+
+type PlaceSyncService interface {
+	SyncDue(ctx context.Context) error
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.PlaceUpdate, error)
+	Accept(ctx context.Context, restaurantID uuid.UUID, id int, userID *uuid.UUID) (*dto.PlaceUpdate, error)
+	Dismiss(ctx context.Context, restaurantID uuid.UUID, id int, userID *uuid.UUID) (*dto.PlaceUpdate, error)
+}
+
+type placeSyncService struct {
+	cfg       *config.Jobs
+	db        *database.DB
+	auditSvc  AuditService
+	googleSvc GoogleService
+}
+
+func NewPlaceSyncService(cfg *config.Jobs, db *database.DB, auditSvc AuditService, googleSvc GoogleService) *placeSyncService {
+	return &placeSyncService{
+		cfg:       cfg,
+		db:        db,
+		auditSvc:  auditSvc,
+		googleSvc: googleSvc,
+	}
+}
+
+// SyncDue re-fetches the place details of the restaurants not synced for PlaceSyncMaxAge.
+func (s *placeSyncService) SyncDue(ctx context.Context) error {
+	dbRestaurants, err := s.db.Queries.GetRestaurantsDueForPlaceSync(ctx, repository.GetRestaurantsDueForPlaceSyncParams{
+		SyncedBefore: time.Now().Add(-s.cfg.PlaceSyncMaxAge),
+		BatchSize:    int32(s.cfg.PlaceSyncBatchSize),
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching restaurants due for place sync: %w", err)
+	}
+
+	for i := range dbRestaurants {
+		if err := s.sync(ctx, &dbRestaurants[i]); err != nil {
+			if errors.Is(err, ErrGoogleServiceUnavailable) || ctx.Err() != nil {
+				return err
+			}
+			log.Printf("error syncing place details of restaurant ID %s: %v", dbRestaurants[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *placeSyncService) sync(ctx context.Context, dueRestaurant *repository.Restaurant) error {
+	details, err := s.googleSvc.GetDetails(ctx, dueRestaurant.PlaceID)
+	if err != nil {
+		if !errors.Is(err, ErrGoogleInvalidPlaceID) {
+			return err
+		}
+		// The place is gone from the provider, keep the restaurant as is until the next sync
+		log.Printf("place ID %s of restaurant ID %s is no longer valid", dueRestaurant.PlaceID, dueRestaurant.ID)
+		return s.db.Queries.SetRestaurantPlaceSynced(ctx, dueRestaurant.ID)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	// The owner may have edited the restaurant during the provider call, the diff is made on the locked row
+	dbRestaurant, err := qtx.GetRestaurantByIDForUpdate(ctx, dueRestaurant.ID)
+	if err != nil {
+		return fmt.Errorf("error fetching restaurant by ID %s: %w", dueRestaurant.ID, err)
+	}
+	restaurant := &dbRestaurant
+
+	current := repository.UpdateRestaurantPlaceDetailsParams{
+		Name:    restaurant.Name,
+		Address: restaurant.Address,
+		Lat:     restaurant.Lat,
+		Lng:     restaurant.Lng,
+		Phone:   restaurant.Phone,
+		ID:      restaurant.ID,
+	}
+	provider := repository.UpdateRestaurantPlaceDetailsParams{
+		Name:    details.Name,
+		Address: details.Address,
+		Lat:     details.Lat,
+		Lng:     details.Lng,
+		Phone:   details.Phone,
+		ID:      restaurant.ID,
+	}
+
+	updated := current
+	applied := false
+	for _, field := range placeFields {
+		oldValue, newValue := placeValue(&current, field), placeValue(&provider, field)
+		if equalPlaceValues(oldValue, newValue) {
+			continue
+		}
+
+		status := enum.PlaceUpdateStatusApplied
+		if slices.Contains(restaurant.OwnerEditedFields, string(field)) {
+			status = enum.PlaceUpdateStatusSuggested
+
+			// Do not raise the same suggestion again once suggested or dismissed
+			known, err := qtx.IsRestaurantPlaceUpdateKnown(ctx, repository.IsRestaurantPlaceUpdateKnownParams{
+				RestaurantID: restaurant.ID,
+				Field:        string(field),
+				NewValue:     newValue,
+			})
+			if err != nil {
+				return fmt.Errorf("error checking place update of field %s for restaurant ID %s: %w", field, restaurant.ID, err)
+			}
+			if known {
+				continue
+			}
+
+			err = qtx.SupersedeSuggestedRestaurantPlaceUpdates(ctx, repository.SupersedeSuggestedRestaurantPlaceUpdatesParams{
+				RestaurantID: restaurant.ID,
+				Field:        string(field),
+			})
+			if err != nil {
+				return fmt.Errorf("error superseding place updates of field %s for restaurant ID %s: %w", field, restaurant.ID, err)
+			}
+		} else {
+			if err := setPlaceValue(&updated, field, newValue); err != nil {
+				return err
+			}
+			applied = true
+		}
+
+		_, err = qtx.CreateRestaurantPlaceUpdate(ctx, repository.CreateRestaurantPlaceUpdateParams{
+			RestaurantID: restaurant.ID,
+			Field:        string(field),
+			OldValue:     oldValue,
+			NewValue:     newValue,
+			Status:       string(status),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating place update of field %s for restaurant ID %s: %w", field, restaurant.ID, err)
+		}
+	}
+
+	if applied {
+		if err := qtx.UpdateRestaurantPlaceDetails(ctx, updated); err != nil {
+			return fmt.Errorf("error updating place details of restaurant ID %s: %w", restaurant.ID, err)
+		}
+	}
+
+	if err := qtx.SetRestaurantPlaceSynced(ctx, restaurant.ID); err != nil {
+		return fmt.Errorf("error setting place sync date of restaurant ID %s: %w", restaurant.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if applied {
+		// Provider changes have no actor
+		updatedRestaurant := *restaurant
+		updatedRestaurant.Name = updated.Name
+		updatedRestaurant.Address = updated.Address
+		updatedRestaurant.Lat = updated.Lat
+		updatedRestaurant.Lng = updated.Lng
+		updatedRestaurant.Phone = updated.Phone
+		s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+			RestaurantID: restaurant.ID,
+			Action:       enum.AuditActionUpdate,
+			EntityType:   enum.AuditEntityRestaurant,
+			EntityID:     restaurant.ID.String(),
+			Before:       dto.NewRestaurant(restaurant),
+			After:        dto.NewRestaurant(&updatedRestaurant),
+		})
+	}
+
+	return nil
+}
+
+func (s *placeSyncService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.PlaceUpdate, error) {
+	dbUpdates, err := s.db.Queries.GetRestaurantPlaceUpdatesByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching place updates for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	updates := make([]*dto.PlaceUpdate, len(dbUpdates))
+	for i := range dbUpdates {
+		updates[i] = dto.NewPlaceUpdate(&dbUpdates[i])
+	}
+	return updates, nil
+}
+
+// Accept applies the suggested value, userID is nil when an integration resolves the update with its API key.
+func (s *placeSyncService) Accept(ctx context.Context, restaurantID uuid.UUID, id int, userID *uuid.UUID) (*dto.PlaceUpdate, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbUpdate, err := s.getSuggested(ctx, qtx, restaurantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRestaurant, err := qtx.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", restaurantID, err)
+	}
+
+	details := repository.UpdateRestaurantPlaceDetailsParams{
+		Name:    dbRestaurant.Name,
+		Address: dbRestaurant.Address,
+		Lat:     dbRestaurant.Lat,
+		Lng:     dbRestaurant.Lng,
+		Phone:   dbRestaurant.Phone,
+		ID:      restaurantID,
+	}
+	if err := setPlaceValue(&details, enum.RestaurantField(dbUpdate.Field), dbUpdate.NewValue); err != nil {
+		return nil, err
+	}
+
+	if err := qtx.UpdateRestaurantPlaceDetails(ctx, details); err != nil {
+		return nil, fmt.Errorf("error updating place details of restaurant ID %s: %w", restaurantID, err)
+	}
+
+	resolvedUpdate, err := qtx.ResolveRestaurantPlaceUpdate(ctx, repository.ResolveRestaurantPlaceUpdateParams{
+		Status:           string(enum.PlaceUpdateStatusAccepted),
+		ResolvedByUserID: userID,
+		ID:               dbUpdate.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error accepting place update %d: %w", id, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	accepted := dto.NewPlaceUpdate(&resolvedUpdate)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  userID,
+		Action:       enum.AuditActionAccept,
+		EntityType:   enum.AuditEntityPlaceUpdate,
+		EntityID:     strconv.Itoa(id),
+		Before:       dto.NewPlaceUpdate(dbUpdate),
+		After:        accepted,
+	})
+
+	return accepted, nil
+}
+
+func (s *placeSyncService) Dismiss(ctx context.Context, restaurantID uuid.UUID, id int, userID *uuid.UUID) (*dto.PlaceUpdate, error) {
+	dbUpdate, err := s.getSuggested(ctx, s.db.Queries, restaurantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedUpdate, err := s.db.Queries.ResolveRestaurantPlaceUpdate(ctx, repository.ResolveRestaurantPlaceUpdateParams{
+		Status:           string(enum.PlaceUpdateStatusDismissed),
+		ResolvedByUserID: userID,
+		ID:               dbUpdate.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error dismissing place update %d: %w", id, err)
+	}
+
+	dismissed := dto.NewPlaceUpdate(&resolvedUpdate)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  userID,
+		Action:       enum.AuditActionDismiss,
+		EntityType:   enum.AuditEntityPlaceUpdate,
+		EntityID:     strconv.Itoa(id),
+		Before:       dto.NewPlaceUpdate(dbUpdate),
+		After:        dismissed,
+	})
+
+	return dismissed, nil
+}
+
+func (s *placeSyncService) getSuggested(ctx context.Context, q *repository.Queries, restaurantID uuid.UUID, id int) (*repository.RestaurantPlaceUpdate, error) {
+	dbUpdate, err := q.GetRestaurantPlaceUpdateByID(ctx, repository.GetRestaurantPlaceUpdateByIDParams{
+		ID:           int32(id),
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlaceUpdateNotFound
+		}
+		return nil, fmt.Errorf("error fetching place update %d: %w", id, err)
+	}
+	if dbUpdate.Status != string(enum.PlaceUpdateStatusSuggested) {
+		return nil, ErrPlaceUpdateNotSuggested
+	}
+
+	return &dbUpdate, nil
+}
+
+// placeValue returns the field of the details as stored in a place update.
+func placeValue(details *repository.UpdateRestaurantPlaceDetailsParams, field enum.RestaurantField) *string {
+	switch field {
+	case enum.RestaurantFieldName:
+		return &details.Name
+	case enum.RestaurantFieldAddress:
+		return &details.Address
+	case enum.RestaurantFieldPhone:
+		return details.Phone
+	case enum.RestaurantFieldLocation:
+		if details.Lat == nil || details.Lng == nil {
+			return nil
+		}
+		location := strconv.FormatFloat(*details.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(*details.Lng, 'f', -1, 64)
+		return &location
+	}
+	return nil
+}
+
+// setPlaceValue writes a place update value back into the details.
+func setPlaceValue(details *repository.UpdateRestaurantPlaceDetailsParams, field enum.RestaurantField, value *string) error {
+	switch field {
+	case enum.RestaurantFieldName:
+		if value != nil {
+			details.Name = *value
+		}
+	case enum.RestaurantFieldAddress:
+		if value != nil {
+			details.Address = *value
+		}
+	case enum.RestaurantFieldPhone:
+		details.Phone = value
+	case enum.RestaurantFieldLocation:
+		if value == nil {
+			details.Lat, details.Lng = nil, nil
+			return nil
+		}
+		latStr, lngStr, ok := strings.Cut(*value, ",")
+		if !ok {
+			return fmt.Errorf("invalid location %q", *value)
+		}
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid location latitude %q: %w", latStr, err)
+		}
+		lng, err := strconv.ParseFloat(lngStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid location longitude %q: %w", lngStr, err)
+		}
+		details.Lat, details.Lng = &lat, &lng
+	}
+	return nil
+}
+
+func equalPlaceValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/cache"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token already used, please log in again")
+
+// concurrentRefreshGrace is how long a token consumed by another request is refused without being treated as reused.
+const concurrentRefreshGrace = 10 * time.Second
+
+// RefreshTokenService issues rotating refresh tokens. Presenting a rotated token again revokes its whole family.
+type RefreshTokenService interface {
+	Issue(ctx context.Context, userID, oat string) (string, error)
+	Consume(ctx context.Context, refreshToken string) (*dto.RefreshTokenFamily, error)
+	Rotate(ctx context.Context, family *dto.RefreshTokenFamily, oat string) (string, error)
+	RevokeByOAT(ctx context.Context, userID, oat string) error
+	RevokeByUserID(ctx context.Context, userID string, exceptOATs ...string) error
+}
+
+type refreshTokenService struct {
+	cfg      *config.Security
+	cache    cache.Cache
+	tokenSvc TokenService
+}
+
+func NewRefreshTokenService(cfg *config.Security, cache cache.Cache, tokenSvc TokenService) *refreshTokenService {
+	return &refreshTokenService{
+		cfg:      cfg,
+		cache:    cache,
+		tokenSvc: tokenSvc,
+	}
+}
+
+// Issue starts a new family for the session opened with the raw OAT.
+func (s *refreshTokenService) Issue(ctx context.Context, userID, oat string) (string, error) {
+	family := &dto.RefreshTokenFamily{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(s.cfg.RefreshTokenFamilyDuration),
+	}
+
+	err := s.cache.AddToSet(ctx, familyIndexKey(userID), family.ID, s.cfg.RefreshTokenFamilyDuration)
+	if err != nil {
+		return "", err
+	}
+
+	return s.Rotate(ctx, family, oat)
+}
+
+// Consume validates the refresh token and returns its family, a token that was already rotated revokes the family.
+// Each token is claimed atomically, so concurrent refreshes with the same token cannot both succeed. The loser
+// of a race within concurrentRefreshGrace is refused without revoking the family of the legitimate client.
+func (s *refreshTokenService) Consume(ctx context.Context, refreshToken string) (*dto.RefreshTokenFamily, error) {
+	familyID, err := s.tokenSvc.VerifyOAT(ctx, keys.RefreshToken, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := decodeOAT(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	usedKey := cache.GenerateKey(string(keys.RefreshTokenUsed), token)
+	now := time.Now()
+	claimed, err := s.cache.SetNX(ctx, usedKey, []byte(strconv.FormatInt(now.UnixMilli(), 10)), s.cfg.RefreshTokenFamilyDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	family, err := s.getFamily(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		usedAt, err := s.cache.Get(ctx, usedKey)
+		if err != nil && !errors.Is(err, cache.ErrCacheNotFound) {
+			return nil, err
+		}
+		if usedAtMilli, err := strconv.ParseInt(string(usedAt), 10, 64); err == nil && now.Sub(time.UnixMilli(usedAtMilli)) < concurrentRefreshGrace {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	if !claimed || token != family.Token {
+		if err := s.revokeFamily(ctx, family); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return family, nil
+}
+
+// Rotate issues the next token of the family, previous tokens stay known so that replaying them is detected.
+func (s *refreshTokenService) Rotate(ctx context.Context, family *dto.RefreshTokenFamily, oat string) (string, error) {
+	ttl := min(s.cfg.RefreshTokenDuration, time.Until(family.ExpiresAt))
+	if ttl <= 0 {
+		return "", ErrInvalidToken
+	}
+
+	refreshToken, err := s.tokenSvc.GenerateOAT(ctx, keys.RefreshToken, family.ID, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	family.Token, err = decodeOAT(refreshToken)
+	if err != nil {
+		return "", err
+	}
+	family.OAT = oat
+
+	data, err := json.Marshal(family)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling refresh token family: %w", err)
+	}
+
+	err = s.cache.Set(ctx, cache.GenerateKey(string(keys.RefreshTokenFamily), family.ID), data, time.Until(family.ExpiresAt))
+	if err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
+
+// RevokeByOAT revokes the family of the session opened with the raw OAT.
+func (s *refreshTokenService) RevokeByOAT(ctx context.Context, userID, oat string) error {
+	return s.revokeByUserID(ctx, userID, func(family *dto.RefreshTokenFamily) bool {
+		return family.OAT == oat
+	})
+}
+
+// RevokeByUserID revokes every family of the user, except the ones of the sessions opened with exceptOATs.
+func (s *refreshTokenService) RevokeByUserID(ctx context.Context, userID string, exceptOATs ...string) error {
+	return s.revokeByUserID(ctx, userID, func(family *dto.RefreshTokenFamily) bool {
+		return !slices.Contains(exceptOATs, family.OAT)
+	})
+}
+
+func (s *refreshTokenService) revokeByUserID(ctx context.Context, userID string, match func(family *dto.RefreshTokenFamily) bool) error {
+	familyIDs, err := s.cache.GetSet(ctx, familyIndexKey(userID))
+	if err != nil {
+		return err
+	}
+
+	for _, familyID := range familyIDs {
+		family, err := s.getFamily(ctx, familyID)
+		if err != nil {
+			if errors.Is(err, ErrInvalidToken) {
+				if err := s.cache.RemoveFromSet(ctx, familyIndexKey(userID), familyID); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if !match(family) {
+			continue
+		}
+		if err := s.revokeFamily(ctx, family); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeFamily revokes every token of the family along with the OAT it last issued.
+func (s *refreshTokenService) revokeFamily(ctx context.Context, family *dto.RefreshTokenFamily) error {
+	if err := s.tokenSvc.RevokeOATs(ctx, keys.RefreshToken, family.ID); err != nil {
+		return err
+	}
+
+	if err := s.tokenSvc.RevokeOAT(ctx, keys.AuthToken, family.UserID, family.OAT); err != nil {
+		return err
+	}
+
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.RefreshTokenFamily), family.ID)); err != nil {
+		return err
+	}
+
+	return s.cache.RemoveFromSet(ctx, familyIndexKey(family.UserID), family.ID)
+}
+
+func (s *refreshTokenService) getFamily(ctx context.Context, familyID string) (*dto.RefreshTokenFamily, error) {
+	data, err := s.cache.Get(ctx, cache.GenerateKey(string(keys.RefreshTokenFamily), familyID))
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	var family dto.RefreshTokenFamily
+	if err := json.Unmarshal(data, &family); err != nil {
+		return nil, fmt.Errorf("error unmarshaling refresh token family: %w", err)
+	}
+
+	return &family, nil
+}
+
+// familyIndexKey is the key of the set holding the refresh token families of the user.
+func familyIndexKey(userID string) string {
+	return cache.GenerateKey(string(keys.RefreshTokenFamily)+"_index", userID)
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/internal/database"
@@ -22,18 +24,25 @@ var (
 type RestaurantService interface {
 	Create(ctx context.Context, placeID string, userID uuid.UUID) (*dto.Restaurant, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*dto.Restaurant, error)
+	GetPublicByID(ctx context.Context, id uuid.UUID) (*dto.Restaurant, error)
+	GetNearby(ctx context.Context, filter dto.NearbyRestaurantsFilter) ([]*dto.Restaurant, error)
 	GetRestaurantsByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.Restaurant, error)
+	Update(ctx context.Context, id uuid.UUID, restaurant *dto.UpdateRestaurant) (*dto.Restaurant, error)
 }
 
 type restaurantService struct {
-	db        *database.DB
-	googleSvc GoogleService
+	db              *database.DB
+	auditSvc        AuditService
+	googleSvc       GoogleService
+	openingHoursSvc OpeningHoursService
 }
 
-func NewRestaurantService(db *database.DB, googleSvc GoogleService) RestaurantService {
+func NewRestaurantService(db *database.DB, auditSvc AuditService, googleSvc GoogleService, openingHoursSvc OpeningHoursService) RestaurantService {
 	return &restaurantService{
-		db:        db,
-		googleSvc: googleSvc,
+		db:              db,
+		auditSvc:        auditSvc,
+		googleSvc:       googleSvc,
+		openingHoursSvc: openingHoursSvc,
 	}
 }
 
@@ -49,6 +58,32 @@ func (s *restaurantService) GetByID(ctx context.Context, id uuid.UUID) (*dto.Res
 	return dto.NewRestaurant(&dbRestaurant), nil
 }
 
+func (s *restaurantService) GetPublicByID(ctx context.Context, id uuid.UUID) (*dto.Restaurant, error) {
+	restaurant, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openingHoursSvc.SetOpeningStatus(ctx, restaurant, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return restaurant, nil
+}
+
+func (s *restaurantService) GetNearby(ctx context.Context, filter dto.NearbyRestaurantsFilter) ([]*dto.Restaurant, error) {
+	dbRestaurants, err := s.db.Queries.GetNearbyRestaurants(ctx, filter.ToParams())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurants near %f,%f: %w", filter.Lat, filter.Lng, err)
+	}
+
+	restaurants := make([]*dto.Restaurant, len(dbRestaurants))
+	for i := range dbRestaurants {
+		restaurants[i] = dto.NewNearbyRestaurant(&dbRestaurants[i])
+	}
+	return restaurants, nil
+}
+
 func (s *restaurantService) GetRestaurantsByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.Restaurant, error) {
 	dbRestaurants, err := s.db.Queries.GetRestaurantsByUserID(ctx, userID)
 	if err != nil {
@@ -90,15 +125,29 @@ func (s *restaurantService) Create(ctx context.Context, placeID string, userID u
 		return nil, ErrRestaurantAlreadyTaken
 	}
 
+	settings := settingsForCountry(createRestaurantDTO.CountryCode, createRestaurantDTO.Timezone)
+	createRestaurantDTO.Timezone = settings.Timezone
+
 	restaurant, err := qtx.CreateRestaurant(ctx, createRestaurantDTO.ToParams())
 	if err != nil {
 		return nil, fmt.Errorf("error creating restaurant: %w", err)
 	}
 
+	_, err = qtx.CreateRestaurantSettings(ctx, repository.CreateRestaurantSettingsParams{
+		RestaurantID:     restaurant.ID,
+		Currency:         settings.Currency,
+		Locale:           settings.Locale,
+		PricesIncludeTax: settings.PricesIncludeTax,
+		DefaultTaxRate:   settings.DefaultTaxRate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating restaurant settings: %w", err)
+	}
+
 	err = qtx.AddRestaurantUser(ctx, repository.AddRestaurantUserParams{
 		RestaurantID: restaurant.ID,
 		UserID:       userID,
-		RoleID:       int16(enum.RoleOwner),
+		RoleID:       int32(enum.RoleOwner),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error adding restaurant user: %w", err)
@@ -108,5 +157,71 @@ func (s *restaurantService) Create(ctx context.Context, placeID string, userID u
 		return nil, err
 	}
 
-	return dto.NewRestaurant(&restaurant), nil
+	created := dto.NewRestaurant(&restaurant)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurant.ID,
+		ActorUserID:  &userID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityRestaurant,
+		EntityID:     restaurant.ID.String(),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+func (s *restaurantService) Update(ctx context.Context, id uuid.UUID, restaurant *dto.UpdateRestaurant) (*dto.Restaurant, error) {
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantNotFound
+		}
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", id, err)
+	}
+
+	// Edited fields are no longer overwritten by the places sync, changes are suggested instead
+	params := repository.UpdateRestaurantDetailsParams{
+		Name:              dbRestaurant.Name,
+		Address:           dbRestaurant.Address,
+		Phone:             dbRestaurant.Phone,
+		OwnerEditedFields: dbRestaurant.OwnerEditedFields,
+		ID:                id,
+	}
+	edited := func(fields ...enum.RestaurantField) {
+		for _, field := range fields {
+			if !slices.Contains(params.OwnerEditedFields, string(field)) {
+				params.OwnerEditedFields = append(params.OwnerEditedFields, string(field))
+			}
+		}
+	}
+	if restaurant.Name != nil {
+		params.Name = *restaurant.Name
+		edited(enum.RestaurantFieldName)
+	}
+	if restaurant.Address != nil {
+		params.Address = *restaurant.Address
+		// Coordinates of the provider address would not match the owner one
+		edited(enum.RestaurantFieldAddress, enum.RestaurantFieldLocation)
+	}
+	if restaurant.Phone != nil {
+		params.Phone = restaurant.Phone
+		edited(enum.RestaurantFieldPhone)
+	}
+
+	updatedRestaurant, err := s.db.Queries.UpdateRestaurantDetails(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error updating restaurant ID %s: %w", id, err)
+	}
+
+	updated := dto.NewRestaurant(&updatedRestaurant)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: id,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityRestaurant,
+		EntityID:     id.String(),
+		Before:       dto.NewRestaurant(&dbRestaurant),
+		After:        updated,
+	})
+
+	return updated, nil
 }
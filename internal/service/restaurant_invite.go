@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+var (
+	ErrRestaurantInviteNotFound    = errors.New("restaurant invite not found")
+	ErrRestaurantInviteExists      = errors.New("a pending invite already exists for this email")
+	ErrInviteeAlreadyMember        = errors.New("user is already a member of the restaurant")
+	ErrInviteeRegistrationRequired = errors.New("no account exists for this email, name and password are required to accept the invite")
+)
+
+type RestaurantInviteService interface {
+	Create(ctx context.Context, invite *dto.CreateRestaurantInvite) (*dto.RestaurantInvite, error)
+	GetPendingByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.RestaurantInvite, error)
+	Resend(ctx context.Context, restaurantID uuid.UUID, inviteID int) error
+	Cancel(ctx context.Context, restaurantID uuid.UUID, inviteID int, userID uuid.UUID) (*dto.RestaurantInvite, error)
+	Accept(ctx context.Context, token string, registration *dto.CreateUser) (*dto.RestaurantInvite, error)
+}
+
+type restaurantInviteService struct {
+	cfg       *config.App
+	db        *database.DB
+	auditSvc  AuditService
+	mailerSvc MailerService
+	roleSvc   RoleService
+	tokenSvc  TokenService
+}
+
+func NewRestaurantInviteService(cfg *config.App, db *database.DB, auditSvc AuditService, tokenSvc TokenService, mailerSvc MailerService, roleSvc RoleService) *restaurantInviteService {
+	return &restaurantInviteService{
+		cfg:       cfg,
+		db:        db,
+		auditSvc:  auditSvc,
+		mailerSvc: mailerSvc,
+		roleSvc:   roleSvc,
+		tokenSvc:  tokenSvc,
+	}
+}
+
+func (s *restaurantInviteService) Create(ctx context.Context, invite *dto.CreateRestaurantInvite) (*dto.RestaurantInvite, error) {
+	if err := s.roleSvc.CheckAssignable(ctx, invite.RestaurantID, invite.InvitedByUserID, invite.RoleID); err != nil {
+		return nil, err
+	}
+
+	dbUser, err := s.db.Queries.GetVerifiedUserByEmail(ctx, invite.Email)
+	if err == nil {
+		_, err = s.db.Queries.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+			RestaurantID: invite.RestaurantID,
+			UserID:       dbUser.ID,
+		})
+		if err == nil {
+			return nil, ErrInviteeAlreadyMember
+		}
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error checking membership of %s for restaurant ID %s: %w", invite.Email, invite.RestaurantID, err)
+	}
+
+	inviteExists, err := s.db.Queries.PendingRestaurantInviteExists(ctx, repository.PendingRestaurantInviteExistsParams{
+		RestaurantID: invite.RestaurantID,
+		Email:        invite.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error checking pending invites of %s for restaurant ID %s: %w", invite.Email, invite.RestaurantID, err)
+	}
+	if inviteExists {
+		return nil, ErrRestaurantInviteExists
+	}
+
+	dbInvite, err := s.db.Queries.CreateRestaurantInvite(ctx, invite.ToParams())
+	if err != nil {
+		return nil, fmt.Errorf("error creating invite for restaurant ID %s: %w", invite.RestaurantID, err)
+	}
+
+	if err := s.sendInvite(ctx, &dbInvite); err != nil {
+		return nil, err
+	}
+
+	created := dto.NewRestaurantInvite(&dbInvite)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: invite.RestaurantID,
+		ActorUserID:  &invite.InvitedByUserID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityInvite,
+		EntityID:     strconv.Itoa(int(dbInvite.ID)),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+func (s *restaurantInviteService) GetPendingByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.RestaurantInvite, error) {
+	dbInvites, err := s.db.Queries.GetPendingRestaurantInvitesByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pending invites for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	invites := make([]*dto.RestaurantInvite, len(dbInvites))
+	for i := range dbInvites {
+		invites[i] = dto.NewPendingRestaurantInvite(&dbInvites[i])
+	}
+	return invites, nil
+}
+
+// Resend mails the invite again, the accept link gets a fresh lifetime.
+func (s *restaurantInviteService) Resend(ctx context.Context, restaurantID uuid.UUID, inviteID int) error {
+	dbInvite, err := s.getPending(ctx, restaurantID, inviteID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sendInvite(ctx, dbInvite); err != nil {
+		return err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionResend,
+		EntityType:   enum.AuditEntityInvite,
+		EntityID:     strconv.Itoa(inviteID),
+	})
+
+	return nil
+}
+
+func (s *restaurantInviteService) Cancel(ctx context.Context, restaurantID uuid.UUID, inviteID int, userID uuid.UUID) (*dto.RestaurantInvite, error) {
+	dbInvite, err := s.getPending(ctx, restaurantID, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	canceledInvite, err := s.db.Queries.CancelRestaurantInvite(ctx, repository.CancelRestaurantInviteParams{
+		CanceledByUserID: &userID,
+		ID:               int32(inviteID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error canceling restaurant invite %d: %w", inviteID, err)
+	}
+
+	if err := s.tokenSvc.RevokeSPT(ctx, keys.RestaurantInvite, strconv.Itoa(inviteID)); err != nil {
+		return nil, err
+	}
+
+	canceled := dto.NewRestaurantInvite(&canceledInvite)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &userID,
+		Action:       enum.AuditActionCancel,
+		EntityType:   enum.AuditEntityInvite,
+		EntityID:     strconv.Itoa(inviteID),
+		Before:       dto.NewRestaurantInvite(dbInvite),
+		After:        canceled,
+	})
+
+	return canceled, nil
+}
+
+// Accept adds the invitee to the restaurant team. When no verified account exists for the invited email,
+// registration details are required and the account is created as verified since the token proves the email ownership.
+func (s *restaurantInviteService) Accept(ctx context.Context, token string, registration *dto.CreateUser) (*dto.RestaurantInvite, error) {
+	inviteIDStr, err := s.tokenSvc.VerifySPT(ctx, keys.RestaurantInvite, token)
+	if err != nil {
+		return nil, err
+	}
+
+	inviteID, err := strconv.Atoi(inviteIDStr)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbInvite, err := qtx.GetRestaurantInviteByID(ctx, int32(inviteID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantInviteNotFound
+		}
+		return nil, fmt.Errorf("error fetching restaurant invite %d: %w", inviteID, err)
+	}
+	if dbInvite.AcceptedAt != nil || dbInvite.CanceledAt != nil {
+		return nil, ErrRestaurantInviteNotFound
+	}
+
+	dbUser, err := qtx.GetVerifiedUserByEmail(ctx, dbInvite.Email)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error fetching user by email %s: %w", dbInvite.Email, err)
+		}
+		if registration == nil {
+			return nil, ErrInviteeRegistrationRequired
+		}
+
+		hashedPassword, err := security.HashPassword(registration.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		dbUser, err = qtx.CreateUser(ctx, dto.CreateUser{
+			Name:     registration.Name,
+			Email:    dbInvite.Email,
+			Password: hashedPassword,
+		}.ToParams())
+		if err != nil {
+			return nil, fmt.Errorf("error creating user: %w", err)
+		}
+
+		isEmailVerified := true
+		dbUser, err = qtx.UpdateUser(ctx, dto.UpdateUser{
+			IsEmailVerified: &isEmailVerified,
+		}.ToParams(dbUser.ID))
+		if err != nil {
+			return nil, fmt.Errorf("error updating user: %w", err)
+		}
+	}
+
+	_, err = qtx.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: dbInvite.RestaurantID,
+		UserID:       dbUser.ID,
+	})
+	if err == nil {
+		return nil, ErrInviteeAlreadyMember
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", dbUser.ID, dbInvite.RestaurantID, err)
+	}
+
+	err = qtx.AddRestaurantUser(ctx, repository.AddRestaurantUserParams{
+		UserID:       dbUser.ID,
+		RestaurantID: dbInvite.RestaurantID,
+		RoleID:       dbInvite.RoleID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding user ID %s to restaurant ID %s: %w", dbUser.ID, dbInvite.RestaurantID, err)
+	}
+
+	acceptedInvite, err := qtx.AcceptRestaurantInvite(ctx, dbInvite.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error accepting restaurant invite %d: %w", inviteID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenSvc.RevokeSPT(ctx, keys.RestaurantInvite, inviteIDStr); err != nil {
+		return nil, err
+	}
+
+	accepted := dto.NewRestaurantInvite(&acceptedInvite)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: acceptedInvite.RestaurantID,
+		ActorUserID:  &dbUser.ID,
+		Action:       enum.AuditActionAccept,
+		EntityType:   enum.AuditEntityInvite,
+		EntityID:     inviteIDStr,
+		Before:       dto.NewRestaurantInvite(&dbInvite),
+		After:        accepted,
+	})
+
+	return accepted, nil
+}
+
+func (s *restaurantInviteService) getPending(ctx context.Context, restaurantID uuid.UUID, inviteID int) (*repository.RestaurantInvite, error) {
+	dbInvite, err := s.db.Queries.GetRestaurantInviteByID(ctx, int32(inviteID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantInviteNotFound
+		}
+		return nil, fmt.Errorf("error fetching restaurant invite %d: %w", inviteID, err)
+	}
+	if dbInvite.RestaurantID != restaurantID || dbInvite.AcceptedAt != nil || dbInvite.CanceledAt != nil {
+		return nil, ErrRestaurantInviteNotFound
+	}
+
+	return &dbInvite, nil
+}
+
+func (s *restaurantInviteService) sendInvite(ctx context.Context, invite *repository.RestaurantInvite) error {
+	spt, err := s.tokenSvc.GenerateSPT(ctx, keys.RestaurantInvite, strconv.Itoa(int(invite.ID)), keys.RestaurantInviteTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, invite.RestaurantID)
+	if err != nil {
+		return fmt.Errorf("error fetching restaurant by ID %s: %w", invite.RestaurantID, err)
+	}
+
+	dbInviter, err := s.db.Queries.GetUserByID(ctx, invite.InvitedByUserID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", invite.InvitedByUserID, err)
+	}
+
+	emailtmpl, err := s.mailerSvc.RenderTemplate("restaurant_invite.tmpl", map[string]any{
+		"Host":       s.cfg.Host,
+		"Inviter":    dto.NewUser(&dbInviter),
+		"Restaurant": dto.NewRestaurant(&dbRestaurant),
+		"Token":      spt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{invite.Email},
+		Subject: "You have been invited to join a restaurant team",
+		Body:    emailtmpl,
+	})
+}
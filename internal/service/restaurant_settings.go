@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+)
+
+var ErrRestaurantSettingsNotFound = errors.New("restaurant settings not found")
+
+// countrySettings holds the settings a new restaurant starts with.
+type countrySettings struct {
+	Timezone         string
+	Currency         string
+	Locale           string
+	PricesIncludeTax bool
+	DefaultTaxRate   float64
+}
+
+var defaultCountrySettings = countrySettings{
+	Timezone:         "UTC",
+	Currency:         "EUR",
+	Locale:           "en",
+	PricesIncludeTax: true,
+}
+
+// settingsByCountry is keyed by ISO 3166-1 alpha-2 code, the timezone is only a fallback
+// when the places provider does not return one, e.g. for countries with several timezones.
+var settingsByCountry = map[string]countrySettings{
+	"AT": {"Europe/Vienna", "EUR", "de", true, 10},
+	"BE": {"Europe/Brussels", "EUR", "fr", true, 12},
+	"CA": {"America/Toronto", "CAD", "en", false, 5},
+	"CH": {"Europe/Zurich", "CHF", "de", true, 8.1},
+	"DE": {"Europe/Berlin", "EUR", "de", true, 19},
+	"ES": {"Europe/Madrid", "EUR", "es", true, 10},
+	"FR": {"Europe/Paris", "EUR", "fr", true, 10},
+	"GB": {"Europe/London", "GBP", "en", true, 20},
+	"IE": {"Europe/Dublin", "EUR", "en", true, 13.5},
+	"IT": {"Europe/Rome", "EUR", "it", true, 10},
+	"LU": {"Europe/Luxembourg", "EUR", "fr", true, 3},
+	"MA": {"Africa/Casablanca", "MAD", "fr", true, 10},
+	"NL": {"Europe/Amsterdam", "EUR", "nl", true, 9},
+	"PT": {"Europe/Lisbon", "EUR", "pt", true, 13},
+	"US": {"America/New_York", "USD", "en", false, 0},
+}
+
+// settingsForCountry returns the default settings of a country, timezone is used when valid.
+func settingsForCountry(countryCode, timezone string) countrySettings {
+	settings, ok := settingsByCountry[countryCode]
+	if !ok {
+		settings = defaultCountrySettings
+	}
+
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err == nil {
+			settings.Timezone = timezone
+		}
+	}
+
+	return settings
+}
+
+type RestaurantSettingsService interface {
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.RestaurantSettings, error)
+	Update(ctx context.Context, restaurantID uuid.UUID, settings *dto.UpdateRestaurantSettings) (*dto.RestaurantSettings, error)
+}
+
+type restaurantSettingsService struct {
+	db       *database.DB
+	auditSvc AuditService
+}
+
+func NewRestaurantSettingsService(db *database.DB, auditSvc AuditService) *restaurantSettingsService {
+	return &restaurantSettingsService{
+		db:       db,
+		auditSvc: auditSvc,
+	}
+}
+
+func (s *restaurantSettingsService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.RestaurantSettings, error) {
+	dbSettings, err := s.db.Queries.GetRestaurantSettings(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantSettingsNotFound
+		}
+		return nil, fmt.Errorf("error fetching settings for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	return newRestaurantSettings(&dbSettings), nil
+}
+
+func (s *restaurantSettingsService) Update(ctx context.Context, restaurantID uuid.UUID, settings *dto.UpdateRestaurantSettings) (*dto.RestaurantSettings, error) {
+	if settings.Timezone != nil {
+		if _, err := time.LoadLocation(*settings.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbSettings, err := qtx.GetRestaurantSettings(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantSettingsNotFound
+		}
+		return nil, fmt.Errorf("error fetching settings for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	params := repository.UpdateRestaurantSettingsParams{
+		Currency:         dbSettings.Currency,
+		Locale:           dbSettings.Locale,
+		PricesIncludeTax: dbSettings.PricesIncludeTax,
+		DefaultTaxRate:   dbSettings.DefaultTaxRate,
+		RestaurantID:     restaurantID,
+	}
+	if settings.Currency != nil {
+		params.Currency = *settings.Currency
+	}
+	if settings.Locale != nil {
+		params.Locale = *settings.Locale
+	}
+	if settings.PricesIncludeTax != nil {
+		params.PricesIncludeTax = *settings.PricesIncludeTax
+	}
+	if settings.DefaultTaxRate != nil {
+		params.DefaultTaxRate = *settings.DefaultTaxRate
+	}
+
+	updatedSettings, err := qtx.UpdateRestaurantSettings(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error updating settings for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	timezone := dbSettings.Timezone
+	if settings.Timezone != nil && *settings.Timezone != timezone {
+		timezone = *settings.Timezone
+		err = qtx.UpdateRestaurantTimezone(ctx, repository.UpdateRestaurantTimezoneParams{
+			Timezone: timezone,
+			ID:       restaurantID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error updating timezone for restaurant ID %s: %w", restaurantID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	updated := dto.NewRestaurantSettings(&updatedSettings, timezone)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntitySettings,
+		EntityID:     restaurantID.String(),
+		Before:       newRestaurantSettings(&dbSettings),
+		After:        updated,
+	})
+
+	return updated, nil
+}
+
+func newRestaurantSettings(row *repository.GetRestaurantSettingsRow) *dto.RestaurantSettings {
+	return dto.NewRestaurantSettings(&repository.RestaurantSetting{
+		RestaurantID:     row.RestaurantID,
+		Currency:         row.Currency,
+		Locale:           row.Locale,
+		PricesIncludeTax: row.PricesIncludeTax,
+		DefaultTaxRate:   row.DefaultTaxRate,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}, row.Timezone)
+}
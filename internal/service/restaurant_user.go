@@ -8,34 +8,55 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
 	"github.com/memsbdm/restaurant-api/internal/database/repository"
 	"github.com/memsbdm/restaurant-api/internal/dto"
 )
 
-var ErrRestaurantOrUserNotFound = errors.New("restaurant or user not found")
+var (
+	ErrRestaurantOrUserNotFound = errors.New("restaurant or user not found")
+	ErrRestaurantMemberNotFound = errors.New("restaurant member not found")
+	ErrRestaurantLastOwner      = errors.New("the last owner of the restaurant cannot be removed or demoted")
+	ErrOwnerRoleRequired        = errors.New("only an owner can grant or revoke the owner role")
+)
 
 type RestaurantUserService interface {
 	GetRestaurantUserRoleID(ctx context.Context, restaurantID, userID uuid.UUID) (int, error)
 	GetAnyRestaurantUserLinkByUserID(ctx context.Context, userID uuid.UUID) (*dto.RestaurantUser, error)
+	GetMembers(ctx context.Context, restaurantID uuid.UUID) ([]*dto.RestaurantMember, error)
+	UpdateMemberRole(ctx context.Context, restaurantID, actorID, userID uuid.UUID, roleID enum.RoleID) error
+	RemoveMember(ctx context.Context, restaurantID, actorID, userID uuid.UUID) error
+	Leave(ctx context.Context, restaurantID, userID uuid.UUID) error
 }
 
 type restaurantUserService struct {
-	db *database.DB
+	db       *database.DB
+	auditSvc AuditService
+	roleSvc  RoleService
 }
 
-func NewRestaurantUserService(db *database.DB) *restaurantUserService {
+func NewRestaurantUserService(db *database.DB, auditSvc AuditService, roleSvc RoleService) *restaurantUserService {
 	return &restaurantUserService{
-		db: db,
+		db:       db,
+		auditSvc: auditSvc,
+		roleSvc:  roleSvc,
 	}
 }
 
+// memberAuditData is the membership snapshot stored in the audit log.
+type memberAuditData struct {
+	UserID uuid.UUID   `json:"user_id"`
+	RoleID enum.RoleID `json:"role_id"`
+}
+
+// GetRestaurantUserRoleID returns the role of the user on the restaurant, cascaded from its organization if needed.
 func (s *restaurantUserService) GetRestaurantUserRoleID(ctx context.Context, restaurantID, userID uuid.UUID) (int, error) {
-	role, err := s.db.Queries.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+	role, err := s.db.Queries.GetEffectiveRestaurantUserRoleID(ctx, repository.GetEffectiveRestaurantUserRoleIDParams{
 		RestaurantID: restaurantID,
 		UserID:       userID,
 	})
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return 0, ErrRestaurantOrUserNotFound
 		}
 		return 0, fmt.Errorf("error fetching restaurant user role ID for restaurant ID %s and user ID %s: %w", restaurantID, userID, err)
@@ -44,8 +65,9 @@ func (s *restaurantUserService) GetRestaurantUserRoleID(ctx context.Context, res
 	return int(role), nil
 }
 
+// GetAnyRestaurantUserLinkByUserID returns a restaurant the user can access, directly or through an organization.
 func (s *restaurantUserService) GetAnyRestaurantUserLinkByUserID(ctx context.Context, userID uuid.UUID) (*dto.RestaurantUser, error) {
-	dbRestaurantUser, err := s.db.Queries.GetAnyRestaurantUserLinkByUserID(ctx, userID)
+	access, err := s.db.Queries.GetAnyRestaurantAccessByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrRestaurantOrUserNotFound
@@ -53,5 +75,180 @@ func (s *restaurantUserService) GetAnyRestaurantUserLinkByUserID(ctx context.Con
 		return nil, fmt.Errorf("error fetching restaurant user link by user ID %s: %w", userID, err)
 	}
 
-	return dto.NewRestaurantUser(&dbRestaurantUser), nil
+	return &dto.RestaurantUser{
+		RestaurantID: access.RestaurantID,
+		UserID:       userID,
+		RoleID:       int(access.RoleID),
+	}, nil
+}
+
+// GetMembers returns the direct members of the restaurant followed by the organization members
+// who inherit their access, a direct role always takes precedence.
+func (s *restaurantUserService) GetMembers(ctx context.Context, restaurantID uuid.UUID) ([]*dto.RestaurantMember, error) {
+	dbMembers, err := s.db.Queries.GetRestaurantMembers(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching members for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	members := make([]*dto.RestaurantMember, len(dbMembers))
+	for i := range dbMembers {
+		members[i] = dto.NewRestaurantMember(&dbMembers[i])
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes the direct role of a member, inherited members are managed from their organization.
+func (s *restaurantUserService) UpdateMemberRole(ctx context.Context, restaurantID, actorID, userID uuid.UUID, roleID enum.RoleID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	currentRoleID, err := s.getMemberRoleID(ctx, qtx, restaurantID, userID)
+	if err != nil {
+		return err
+	}
+
+	// The actor must hold every permission of both the current and the new role
+	if err := s.roleSvc.CheckAssignable(ctx, restaurantID, actorID, int(currentRoleID)); err != nil {
+		return err
+	}
+	if err := s.roleSvc.CheckAssignable(ctx, restaurantID, actorID, int(roleID)); err != nil {
+		return err
+	}
+
+	if currentRoleID == enum.RoleOwner && roleID != enum.RoleOwner {
+		if err := s.checkNotLastOwner(ctx, qtx, restaurantID); err != nil {
+			return err
+		}
+	}
+
+	err = qtx.UpdateRestaurantUserRole(ctx, repository.UpdateRestaurantUserRoleParams{
+		RoleID:       int32(roleID),
+		RestaurantID: restaurantID,
+		UserID:       userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating role of user ID %s for restaurant ID %s: %w", userID, restaurantID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &actorID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityMember,
+		EntityID:     userID.String(),
+		Before:       memberAuditData{UserID: userID, RoleID: currentRoleID},
+		After:        memberAuditData{UserID: userID, RoleID: roleID},
+	})
+
+	return nil
+}
+
+func (s *restaurantUserService) RemoveMember(ctx context.Context, restaurantID, actorID, userID uuid.UUID) error {
+	return s.removeMember(ctx, restaurantID, &actorID, userID)
+}
+
+func (s *restaurantUserService) Leave(ctx context.Context, restaurantID, userID uuid.UUID) error {
+	return s.removeMember(ctx, restaurantID, nil, userID)
+}
+
+// removeMember deletes the direct membership, actorID is nil when the member leaves by itself.
+// The membership is checked on every request by RestaurantMiddleware so the active restaurant stops resolving right away.
+func (s *restaurantUserService) removeMember(ctx context.Context, restaurantID uuid.UUID, actorID *uuid.UUID, userID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	currentRoleID, err := s.getMemberRoleID(ctx, qtx, restaurantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if actorID != nil {
+		if err := s.roleSvc.CheckAssignable(ctx, restaurantID, *actorID, int(currentRoleID)); err != nil {
+			return err
+		}
+	}
+
+	if currentRoleID == enum.RoleOwner {
+		if err := s.checkNotLastOwner(ctx, qtx, restaurantID); err != nil {
+			return err
+		}
+	}
+
+	_, err = qtx.DeleteRestaurantUser(ctx, repository.DeleteRestaurantUserParams{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing user ID %s from restaurant ID %s: %w", userID, restaurantID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	entry := &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  actorID,
+		Action:       enum.AuditActionDelete,
+		EntityType:   enum.AuditEntityMember,
+		EntityID:     userID.String(),
+		Before:       memberAuditData{UserID: userID, RoleID: currentRoleID},
+	}
+	if actorID == nil {
+		entry.ActorUserID = &userID
+		entry.Action = enum.AuditActionLeave
+	}
+	s.auditSvc.Record(ctx, entry)
+
+	return nil
+}
+
+func (s *restaurantUserService) getMemberRoleID(ctx context.Context, q *repository.Queries, restaurantID, userID uuid.UUID) (enum.RoleID, error) {
+	roleID, err := q.GetRestaurantUserRoleID(ctx, repository.GetRestaurantUserRoleIDParams{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrRestaurantMemberNotFound
+		}
+		return 0, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", userID, restaurantID, err)
+	}
+
+	return enum.RoleID(roleID), nil
+}
+
+// checkNotLastOwner locks the restaurant until the end of the transaction, so concurrent owners
+// leaving or demoting themselves are counted one after the other.
+func (s *restaurantUserService) checkNotLastOwner(ctx context.Context, q *repository.Queries, restaurantID uuid.UUID) error {
+	if err := q.LockRestaurant(ctx, restaurantID); err != nil {
+		return fmt.Errorf("error locking restaurant ID %s: %w", restaurantID, err)
+	}
+
+	owners, err := q.CountRestaurantUsersByRoleID(ctx, repository.CountRestaurantUsersByRoleIDParams{
+		RestaurantID: restaurantID,
+		RoleID:       int32(enum.RoleOwner),
+	})
+	if err != nil {
+		return fmt.Errorf("error counting owners of restaurant ID %s: %w", restaurantID, err)
+	}
+	if owners <= 1 {
+		return ErrRestaurantLastOwner
+	}
+
+	return nil
 }
@@ -0,0 +1,557 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/cache"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+const (
+	restaurantVerificationCodeLength      = 6
+	maxRestaurantVerificationCodeAttempts = 5
+	competingClaimRejectionReason         = "restaurant verified by another owner"
+	tooManyCodeAttemptsRejectionReason    = "too many invalid verification codes"
+	expiredCodeRejectionReason            = "verification code expired"
+	undeliveredCodeRejectionReason        = "verification code could not be sent"
+)
+
+var (
+	ErrRestaurantAlreadyVerified          = errors.New("restaurant already verified")
+	ErrRestaurantVerificationInProgress   = errors.New("a verification is already in progress for this restaurant")
+	ErrRestaurantVerificationNotFound     = errors.New("restaurant verification not found")
+	ErrRestaurantVerificationNotPending   = errors.New("restaurant verification is not waiting for a code")
+	ErrRestaurantVerificationNotSubmitted = errors.New("restaurant verification is not waiting for a review")
+	ErrInvalidVerificationCode            = errors.New("invalid or expired verification code")
+	ErrVerificationCodeAttemptsExceeded   = errors.New("too many invalid verification codes, please request a new verification")
+	ErrVerificationCodeExpired            = errors.New("verification code expired, please request a new verification")
+)
+
+type RestaurantVerificationService interface {
+	Request(ctx context.Context, verification *dto.CreateRestaurantVerification) (*dto.RestaurantVerification, error)
+	ConfirmCode(ctx context.Context, restaurantID uuid.UUID, code string) (*dto.RestaurantVerification, error)
+	GetLatestByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.RestaurantVerification, error)
+	GetSubmitted(ctx context.Context) ([]*dto.RestaurantVerification, error)
+	Approve(ctx context.Context, verificationID int, adminID uuid.UUID) (*dto.RestaurantVerification, error)
+	Reject(ctx context.Context, verificationID int, adminID uuid.UUID, reason *string) (*dto.RestaurantVerification, error)
+}
+
+type restaurantVerificationService struct {
+	db        *database.DB
+	cache     cache.Cache
+	auditSvc  AuditService
+	mailerSvc MailerService
+}
+
+func NewRestaurantVerificationService(db *database.DB, cache cache.Cache, auditSvc AuditService, mailerSvc MailerService) *restaurantVerificationService {
+	return &restaurantVerificationService{
+		db:        db,
+		cache:     cache,
+		auditSvc:  auditSvc,
+		mailerSvc: mailerSvc,
+	}
+}
+
+func (s *restaurantVerificationService) Request(ctx context.Context, verification *dto.CreateRestaurantVerification) (*dto.RestaurantVerification, error) {
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, verification.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", verification.RestaurantID, err)
+	}
+	if dbRestaurant.IsVerified {
+		return nil, ErrRestaurantAlreadyVerified
+	}
+
+	if err := s.expirePendingCode(ctx, verification.RestaurantID); err != nil {
+		return nil, err
+	}
+
+	inProgress, err := s.db.Queries.RestaurantVerificationInProgressExists(ctx, verification.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking verifications in progress for restaurant ID %s: %w", verification.RestaurantID, err)
+	}
+	if inProgress {
+		return nil, ErrRestaurantVerificationInProgress
+	}
+
+	// A document goes straight to the admin review, an email has to be confirmed first
+	status := enum.VerificationStatusSubmitted
+	if verification.Method == enum.VerificationMethodEmail {
+		status = enum.VerificationStatusPending
+	}
+
+	dbVerification, err := s.db.Queries.CreateRestaurantVerification(ctx, repository.CreateRestaurantVerificationParams{
+		RestaurantID:      verification.RestaurantID,
+		RequestedByUserID: &verification.UserID,
+		Method:            string(verification.Method),
+		Status:            string(status),
+		BusinessEmail:     verification.BusinessEmail,
+		DocumentUrl:       verification.DocumentURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating verification for restaurant ID %s: %w", verification.RestaurantID, err)
+	}
+
+	created := dto.NewRestaurantVerification(&dbVerification)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: verification.RestaurantID,
+		ActorUserID:  &verification.UserID,
+		Action:       enum.AuditActionRequest,
+		EntityType:   enum.AuditEntityVerification,
+		EntityID:     strconv.Itoa(int(dbVerification.ID)),
+		After:        created,
+	})
+
+	if verification.Method == enum.VerificationMethodEmail {
+		if err := s.sendCode(ctx, &dbVerification, &dbRestaurant); err != nil {
+			// Without its code the verification could never be confirmed and would block new requests
+			if rejectErr := s.rejectPending(ctx, &dbVerification, undeliveredCodeRejectionReason); rejectErr != nil {
+				log.Printf("error rejecting verification %d after its code could not be sent: %v", dbVerification.ID, rejectErr)
+			}
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+func (s *restaurantVerificationService) ConfirmCode(ctx context.Context, restaurantID uuid.UUID, code string) (*dto.RestaurantVerification, error) {
+	dbVerification, err := s.db.Queries.GetLatestRestaurantVerificationByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantVerificationNotFound
+		}
+		return nil, fmt.Errorf("error fetching verification for restaurant ID %s: %w", restaurantID, err)
+	}
+	if dbVerification.Status != string(enum.VerificationStatusPending) {
+		return nil, ErrRestaurantVerificationNotPending
+	}
+
+	key := cache.GenerateKey(string(keys.RestaurantVerificationCode), dbVerification.ID)
+	expectedCode, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			if err := s.rejectPending(ctx, &dbVerification, expiredCodeRejectionReason); err != nil {
+				return nil, err
+			}
+			return nil, ErrVerificationCodeExpired
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(code), expectedCode) != 1 {
+		return nil, s.recordInvalidCode(ctx, &dbVerification)
+	}
+
+	if err := s.db.Queries.SubmitRestaurantVerification(ctx, dbVerification.ID); err != nil {
+		return nil, fmt.Errorf("error submitting verification %d: %w", dbVerification.ID, err)
+	}
+
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.RestaurantVerificationFail), dbVerification.ID)); err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.getByID(ctx, int(dbVerification.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionConfirm,
+		EntityType:   enum.AuditEntityVerification,
+		EntityID:     strconv.Itoa(int(dbVerification.ID)),
+		Before:       dto.NewRestaurantVerification(&dbVerification),
+		After:        submitted,
+	})
+
+	return submitted, nil
+}
+
+// recordInvalidCode counts the invalid code, the verification is rejected once too many were submitted
+// and a new one has to be requested.
+func (s *restaurantVerificationService) recordInvalidCode(ctx context.Context, verification *repository.RestaurantVerification) error {
+	failures, err := s.cache.Increment(ctx, cache.GenerateKey(string(keys.RestaurantVerificationFail), verification.ID), keys.RestaurantVerificationCodeDuration)
+	if err != nil {
+		return err
+	}
+	if failures < maxRestaurantVerificationCodeAttempts {
+		return ErrInvalidVerificationCode
+	}
+
+	if err := s.rejectPending(ctx, verification, tooManyCodeAttemptsRejectionReason); err != nil {
+		return err
+	}
+
+	return ErrVerificationCodeAttemptsExceeded
+}
+
+// expirePendingCode rejects the pending email verification of the restaurant once its code has expired,
+// so a new verification can be requested.
+func (s *restaurantVerificationService) expirePendingCode(ctx context.Context, restaurantID uuid.UUID) error {
+	dbVerification, err := s.db.Queries.GetLatestRestaurantVerificationByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("error fetching verification for restaurant ID %s: %w", restaurantID, err)
+	}
+	if dbVerification.Status != string(enum.VerificationStatusPending) {
+		return nil
+	}
+
+	_, err = s.cache.Get(ctx, cache.GenerateKey(string(keys.RestaurantVerificationCode), dbVerification.ID))
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, cache.ErrCacheNotFound) {
+		return err
+	}
+
+	return s.rejectPending(ctx, &dbVerification, expiredCodeRejectionReason)
+}
+
+// rejectPending rejects a verification still waiting for its code and clears the code and its failures.
+func (s *restaurantVerificationService) rejectPending(ctx context.Context, verification *repository.RestaurantVerification, reason string) error {
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.RestaurantVerificationCode), verification.ID)); err != nil {
+		return err
+	}
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.RestaurantVerificationFail), verification.ID)); err != nil {
+		return err
+	}
+
+	err := s.db.Queries.RejectInProgressRestaurantVerifications(ctx, repository.RejectInProgressRestaurantVerificationsParams{
+		RestaurantID:    verification.RestaurantID,
+		RejectionReason: &reason,
+	})
+	if err != nil {
+		return fmt.Errorf("error rejecting verification %d: %w", verification.ID, err)
+	}
+
+	rejected, err := s.getByID(ctx, int(verification.ID))
+	if err != nil {
+		return err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: verification.RestaurantID,
+		Action:       enum.AuditActionReject,
+		EntityType:   enum.AuditEntityVerification,
+		EntityID:     strconv.Itoa(int(verification.ID)),
+		Before:       dto.NewRestaurantVerification(verification),
+		After:        rejected,
+	})
+
+	return nil
+}
+
+func (s *restaurantVerificationService) GetLatestByRestaurantID(ctx context.Context, restaurantID uuid.UUID) (*dto.RestaurantVerification, error) {
+	dbVerification, err := s.db.Queries.GetLatestRestaurantVerificationByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantVerificationNotFound
+		}
+		return nil, fmt.Errorf("error fetching verification for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	return dto.NewRestaurantVerification(&dbVerification), nil
+}
+
+func (s *restaurantVerificationService) GetSubmitted(ctx context.Context) ([]*dto.RestaurantVerification, error) {
+	rows, err := s.db.Queries.GetSubmittedRestaurantVerifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching submitted verifications: %w", err)
+	}
+
+	verifications := make([]*dto.RestaurantVerification, len(rows))
+	for i, row := range rows {
+		verifications[i] = dto.NewRestaurantVerification(&repository.RestaurantVerification{
+			ID:                row.ID,
+			RestaurantID:      row.RestaurantID,
+			RequestedByUserID: row.RequestedByUserID,
+			ReviewedByUserID:  row.ReviewedByUserID,
+			Method:            row.Method,
+			Status:            row.Status,
+			BusinessEmail:     row.BusinessEmail,
+			DocumentUrl:       row.DocumentUrl,
+			RejectionReason:   row.RejectionReason,
+			ProofVerifiedAt:   row.ProofVerifiedAt,
+			ReviewedAt:        row.ReviewedAt,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		})
+		verifications[i].Restaurant = &dto.Restaurant{
+			ID:      row.RestaurantID,
+			Name:    row.RestaurantName,
+			Address: row.RestaurantAddress,
+			PlaceID: row.PlaceID,
+		}
+	}
+
+	return verifications, nil
+}
+
+func (s *restaurantVerificationService) Approve(ctx context.Context, verificationID int, adminID uuid.UUID) (*dto.RestaurantVerification, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbVerification, err := s.getSubmittedForReview(ctx, qtx, verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRestaurant, err := qtx.GetRestaurantByID(ctx, dbVerification.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", dbVerification.RestaurantID, err)
+	}
+
+	// Approvals of competing claims on the same place wait for each other, the later one then sees the place taken
+	if err := qtx.LockRestaurantsByPlaceID(ctx, dbRestaurant.PlaceID); err != nil {
+		return nil, fmt.Errorf("error locking restaurants of place %s: %w", dbRestaurant.PlaceID, err)
+	}
+
+	taken, err := qtx.IsPlaceVerifiedByAnotherRestaurant(ctx, repository.IsPlaceVerifiedByAnotherRestaurantParams{
+		PlaceID: dbRestaurant.PlaceID,
+		ID:      dbRestaurant.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error checking if place %s is already verified: %w", dbRestaurant.PlaceID, err)
+	}
+	if taken {
+		return nil, ErrRestaurantAlreadyTaken
+	}
+
+	reviewedVerification, err := qtx.ReviewRestaurantVerification(ctx, repository.ReviewRestaurantVerificationParams{
+		Status:           string(enum.VerificationStatusApproved),
+		ReviewedByUserID: &adminID,
+		ID:               dbVerification.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error approving verification %d: %w", verificationID, err)
+	}
+
+	if err := qtx.SetRestaurantVerified(ctx, dbRestaurant.ID); err != nil {
+		return nil, fmt.Errorf("error setting restaurant ID %s as verified: %w", dbRestaurant.ID, err)
+	}
+
+	// Other unverified restaurants claiming the same place lose their members
+	competingClaimUsers, err := qtx.GetCompetingRestaurantClaimUsers(ctx, repository.GetCompetingRestaurantClaimUsersParams{
+		PlaceID: dbRestaurant.PlaceID,
+		ID:      dbRestaurant.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching competing claims for place %s: %w", dbRestaurant.PlaceID, err)
+	}
+
+	detached := make(map[uuid.UUID]bool)
+	reason := competingClaimRejectionReason
+	for _, claim := range competingClaimUsers {
+		if detached[claim.RestaurantID] {
+			continue
+		}
+		err := qtx.RejectInProgressRestaurantVerifications(ctx, repository.RejectInProgressRestaurantVerificationsParams{
+			RestaurantID:    claim.RestaurantID,
+			RejectionReason: &reason,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error rejecting verifications for restaurant ID %s: %w", claim.RestaurantID, err)
+		}
+		if err := qtx.DeleteRestaurantUsersByRestaurantID(ctx, claim.RestaurantID); err != nil {
+			return nil, fmt.Errorf("error detaching users from restaurant ID %s: %w", claim.RestaurantID, err)
+		}
+		detached[claim.RestaurantID] = true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	approved := dto.NewRestaurantVerification(&reviewedVerification)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: dbRestaurant.ID,
+		ActorUserID:  &adminID,
+		Action:       enum.AuditActionApprove,
+		EntityType:   enum.AuditEntityVerification,
+		EntityID:     strconv.Itoa(int(dbVerification.ID)),
+		Before:       dto.NewRestaurantVerification(dbVerification),
+		After:        approved,
+	})
+
+	// The approval is committed at this point, notifications are best effort
+	s.notifyRequester(ctx, &reviewedVerification, &dbRestaurant, "restaurant_verification_approved.tmpl", "Your restaurant is verified", nil)
+	for _, claim := range competingClaimUsers {
+		s.notifyDetachedClaim(&claim)
+	}
+
+	return approved, nil
+}
+
+func (s *restaurantVerificationService) Reject(ctx context.Context, verificationID int, adminID uuid.UUID, reason *string) (*dto.RestaurantVerification, error) {
+	dbVerification, err := s.getSubmittedForReview(ctx, s.db.Queries, verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewedVerification, err := s.db.Queries.ReviewRestaurantVerification(ctx, repository.ReviewRestaurantVerificationParams{
+		Status:           string(enum.VerificationStatusRejected),
+		ReviewedByUserID: &adminID,
+		RejectionReason:  reason,
+		ID:               dbVerification.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rejecting verification %d: %w", verificationID, err)
+	}
+
+	dbRestaurant, err := s.db.Queries.GetRestaurantByID(ctx, dbVerification.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurant by ID %s: %w", dbVerification.RestaurantID, err)
+	}
+
+	rejected := dto.NewRestaurantVerification(&reviewedVerification)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: dbRestaurant.ID,
+		ActorUserID:  &adminID,
+		Action:       enum.AuditActionReject,
+		EntityType:   enum.AuditEntityVerification,
+		EntityID:     strconv.Itoa(int(dbVerification.ID)),
+		Before:       dto.NewRestaurantVerification(dbVerification),
+		After:        rejected,
+	})
+
+	s.notifyRequester(ctx, &reviewedVerification, &dbRestaurant, "restaurant_verification_rejected.tmpl", "Your restaurant verification was rejected", reason)
+
+	return rejected, nil
+}
+
+func (s *restaurantVerificationService) getByID(ctx context.Context, verificationID int) (*dto.RestaurantVerification, error) {
+	dbVerification, err := s.db.Queries.GetRestaurantVerificationByID(ctx, int32(verificationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantVerificationNotFound
+		}
+		return nil, fmt.Errorf("error fetching verification %d: %w", verificationID, err)
+	}
+
+	return dto.NewRestaurantVerification(&dbVerification), nil
+}
+
+func (s *restaurantVerificationService) getSubmittedForReview(ctx context.Context, q *repository.Queries, verificationID int) (*repository.RestaurantVerification, error) {
+	dbVerification, err := q.GetRestaurantVerificationByID(ctx, int32(verificationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRestaurantVerificationNotFound
+		}
+		return nil, fmt.Errorf("error fetching verification %d: %w", verificationID, err)
+	}
+	if dbVerification.Status != string(enum.VerificationStatusSubmitted) {
+		return nil, ErrRestaurantVerificationNotSubmitted
+	}
+
+	return &dbVerification, nil
+}
+
+func (s *restaurantVerificationService) sendCode(ctx context.Context, verification *repository.RestaurantVerification, restaurant *repository.Restaurant) error {
+	code, err := security.GenerateNumericCode(restaurantVerificationCodeLength)
+	if err != nil {
+		return err
+	}
+
+	key := cache.GenerateKey(string(keys.RestaurantVerificationCode), verification.ID)
+	if err := s.cache.Set(ctx, key, []byte(code), keys.RestaurantVerificationCodeDuration); err != nil {
+		return err
+	}
+
+	if verification.RequestedByUserID == nil {
+		return fmt.Errorf("requester of verification %d has been deleted", verification.ID)
+	}
+
+	dbUser, err := s.db.Queries.GetUserByID(ctx, *verification.RequestedByUserID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", *verification.RequestedByUserID, err)
+	}
+
+	emailtmpl, err := s.mailerSvc.RenderTemplate("restaurant_verification_code.tmpl", map[string]any{
+		"User":       dto.NewUser(&dbUser),
+		"Restaurant": dto.NewRestaurant(restaurant),
+		"Code":       code,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{*verification.BusinessEmail},
+		Subject: "Your restaurant verification code",
+		Body:    emailtmpl,
+	})
+}
+
+func (s *restaurantVerificationService) notifyRequester(ctx context.Context, verification *repository.RestaurantVerification, restaurant *repository.Restaurant, tmpl, subject string, reason *string) {
+	// Nobody is left to notify once the requester has deleted their account
+	if verification.RequestedByUserID == nil {
+		return
+	}
+
+	dbUser, err := s.db.Queries.GetUserByID(ctx, *verification.RequestedByUserID)
+	if err != nil {
+		log.Printf("error fetching user by ID %s for verification %d: %v", *verification.RequestedByUserID, verification.ID, err)
+		return
+	}
+
+	data := map[string]any{
+		"User":       dto.NewUser(&dbUser),
+		"Restaurant": dto.NewRestaurant(restaurant),
+		"Reason":     "",
+	}
+	if reason != nil {
+		data["Reason"] = *reason
+	}
+
+	emailtmpl, err := s.mailerSvc.RenderTemplate(tmpl, data)
+	if err == nil {
+		err = s.mailerSvc.Send(&mailer.Mail{
+			To:      []string{dbUser.Email},
+			Subject: subject,
+			Body:    emailtmpl,
+		})
+	}
+	if err != nil {
+		log.Printf("error notifying user ID %s about verification %d: %v", dbUser.ID, verification.ID, err)
+	}
+}
+
+func (s *restaurantVerificationService) notifyDetachedClaim(claim *repository.GetCompetingRestaurantClaimUsersRow) {
+	emailtmpl, err := s.mailerSvc.RenderTemplate("restaurant_claim_detached.tmpl", map[string]any{
+		"UserName":       claim.UserName,
+		"RestaurantName": claim.RestaurantName,
+	})
+	if err == nil {
+		err = s.mailerSvc.Send(&mailer.Mail{
+			To:      []string{claim.Email},
+			Subject: "Your access to " + claim.RestaurantName + " has been removed",
+			Body:    emailtmpl,
+		})
+	}
+	if err != nil {
+		log.Printf("error notifying user ID %s about detached restaurant ID %s: %v", claim.UserID, claim.RestaurantID, err)
+	}
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/enum"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+)
+
+var (
+	ErrRoleNotFound            = errors.New("role not found")
+	ErrRoleNameTaken           = errors.New("a role with this name already exists")
+	ErrRoleReadOnly            = errors.New("platform roles cannot be modified")
+	ErrRoleInUse               = errors.New("role is still assigned to members or pending invites")
+	ErrRolePermissionsExceeded = errors.New("cannot assign a role granting permissions you do not have")
+	ErrRoleLimitReached        = fmt.Errorf("a restaurant can have at most %d custom roles", maxCustomRolesPerRestaurant)
+)
+
+// maxCustomRolesPerRestaurant keeps the role list of a restaurant, loaded with its permissions on every
+// role request, to a size the members can actually manage.
+const maxCustomRolesPerRestaurant = 50
+
+type RoleService interface {
+	GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.Role, error)
+	Create(ctx context.Context, restaurantID, actorID uuid.UUID, role *dto.SaveRole) (*dto.Role, error)
+	Update(ctx context.Context, restaurantID, actorID uuid.UUID, roleID int, role *dto.SaveRole) (*dto.Role, error)
+	Delete(ctx context.Context, restaurantID uuid.UUID, roleID int) error
+	CheckAssignable(ctx context.Context, restaurantID, actorID uuid.UUID, roleID int) error
+}
+
+type roleService struct {
+	db       *database.DB
+	auditSvc AuditService
+}
+
+func NewRoleService(db *database.DB, auditSvc AuditService) *roleService {
+	return &roleService{
+		db:       db,
+		auditSvc: auditSvc,
+	}
+}
+
+// GetByRestaurantID returns the platform roles followed by the custom roles of the restaurant.
+func (s *roleService) GetByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]*dto.Role, error) {
+	dbRoles, err := s.db.Queries.GetRolesByRestaurantID(ctx, &restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching roles for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	roleIDs := make([]int32, len(dbRoles))
+	for i := range dbRoles {
+		roleIDs[i] = dbRoles[i].ID
+	}
+
+	permissionsByRole, err := s.getPermissions(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]*dto.Role, len(dbRoles))
+	for i := range dbRoles {
+		roles[i] = dto.NewRole(&dbRoles[i], permissionsByRole[dbRoles[i].ID])
+	}
+	return roles, nil
+}
+
+func (s *roleService) Create(ctx context.Context, restaurantID, actorID uuid.UUID, role *dto.SaveRole) (*dto.Role, error) {
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPermissionsHeld(ctx, restaurantID, actorID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNameAvailable(ctx, restaurantID, 0, role.Name); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	// Concurrent creations wait for each other so they cannot both pass the limit
+	if err := qtx.LockRestaurant(ctx, restaurantID); err != nil {
+		return nil, fmt.Errorf("error locking restaurant ID %s: %w", restaurantID, err)
+	}
+
+	count, err := qtx.CountRolesByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting roles of restaurant ID %s: %w", restaurantID, err)
+	}
+	if count >= maxCustomRolesPerRestaurant {
+		return nil, ErrRoleLimitReached
+	}
+
+	dbRole, err := qtx.CreateRole(ctx, repository.CreateRoleParams{
+		Name:         role.Name,
+		RestaurantID: &restaurantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating role for restaurant ID %s: %w", restaurantID, err)
+	}
+
+	if err := s.setPermissions(ctx, qtx, dbRole.ID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	created := dto.NewRole(&dbRole, permissions)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &actorID,
+		Action:       enum.AuditActionCreate,
+		EntityType:   enum.AuditEntityRole,
+		EntityID:     strconv.Itoa(int(dbRole.ID)),
+		After:        created,
+	})
+
+	return created, nil
+}
+
+// Update renames a custom role and replaces its permissions, members holding it get them on their next request.
+func (s *roleService) Update(ctx context.Context, restaurantID, actorID uuid.UUID, roleID int, role *dto.SaveRole) (*dto.Role, error) {
+	before, err := s.getCustomRoleWithPermissions(ctx, restaurantID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPermissionsHeld(ctx, restaurantID, actorID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNameAvailable(ctx, restaurantID, roleID, role.Name); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbRole, err := qtx.UpdateRoleName(ctx, repository.UpdateRoleNameParams{
+		Name: role.Name,
+		ID:   int32(roleID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating role %d: %w", roleID, err)
+	}
+
+	if err := qtx.DeleteRolePermissions(ctx, dbRole.ID); err != nil {
+		return nil, fmt.Errorf("error deleting permissions of role %d: %w", roleID, err)
+	}
+
+	if err := s.setPermissions(ctx, qtx, dbRole.ID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	updated := dto.NewRole(&dbRole, permissions)
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		ActorUserID:  &actorID,
+		Action:       enum.AuditActionUpdate,
+		EntityType:   enum.AuditEntityRole,
+		EntityID:     strconv.Itoa(roleID),
+		Before:       before,
+		After:        updated,
+	})
+
+	return updated, nil
+}
+
+func (s *roleService) Delete(ctx context.Context, restaurantID uuid.UUID, roleID int) error {
+	before, err := s.getCustomRoleWithPermissions(ctx, restaurantID, roleID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	// Assigning the role takes a key share lock on it, so no member or invite can get it until the role is gone
+	if err := qtx.LockRole(ctx, int32(roleID)); err != nil {
+		return fmt.Errorf("error locking role %d: %w", roleID, err)
+	}
+
+	inUse, err := qtx.RoleInUse(ctx, int32(roleID))
+	if err != nil {
+		return fmt.Errorf("error checking if role %d is in use: %w", roleID, err)
+	}
+	if inUse {
+		return ErrRoleInUse
+	}
+
+	if err := qtx.DeleteRole(ctx, int32(roleID)); err != nil {
+		return fmt.Errorf("error deleting role %d: %w", roleID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	s.auditSvc.Record(ctx, &dto.CreateAuditLog{
+		RestaurantID: restaurantID,
+		Action:       enum.AuditActionDelete,
+		EntityType:   enum.AuditEntityRole,
+		EntityID:     strconv.Itoa(roleID),
+		Before:       before,
+	})
+
+	return nil
+}
+
+// CheckAssignable ensures the role can be given within the restaurant by the actor. Only an owner can hand out
+// the owner role, other actors can only hand out roles whose permissions they hold themselves.
+func (s *roleService) CheckAssignable(ctx context.Context, restaurantID, actorID uuid.UUID, roleID int) error {
+	dbRole, err := s.db.Queries.GetRoleByID(ctx, int32(roleID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return fmt.Errorf("error fetching role %d: %w", roleID, err)
+	}
+	if dbRole.RestaurantID != nil && *dbRole.RestaurantID != restaurantID {
+		return ErrRoleNotFound
+	}
+
+	if enum.RoleID(dbRole.ID) == enum.RoleOwner {
+		isOwner, _, err := s.getActorPermissions(ctx, restaurantID, actorID)
+		if err != nil {
+			return err
+		}
+		if !isOwner {
+			return ErrOwnerRoleRequired
+		}
+		return nil
+	}
+
+	permissionsByRole, err := s.getPermissions(ctx, []int32{dbRole.ID})
+	if err != nil {
+		return err
+	}
+
+	return s.checkPermissionsHeld(ctx, restaurantID, actorID, permissionsByRole[dbRole.ID])
+}
+
+// checkPermissionsHeld ensures the actor holds every given permission within the restaurant, owners hold them all.
+func (s *roleService) checkPermissionsHeld(ctx context.Context, restaurantID, actorID uuid.UUID, permissions []enum.Permission) error {
+	isOwner, actorPermissions, err := s.getActorPermissions(ctx, restaurantID, actorID)
+	if err != nil {
+		return err
+	}
+	if isOwner {
+		return nil
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(actorPermissions, permission) {
+			return ErrRolePermissionsExceeded
+		}
+	}
+
+	return nil
+}
+
+// getActorPermissions reports whether the actor owns the restaurant along with the permissions of their effective role.
+func (s *roleService) getActorPermissions(ctx context.Context, restaurantID, actorID uuid.UUID) (bool, []enum.Permission, error) {
+	actorRoleID, err := s.db.Queries.GetEffectiveRestaurantUserRoleID(ctx, repository.GetEffectiveRestaurantUserRoleIDParams{
+		RestaurantID: restaurantID,
+		UserID:       actorID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil, ErrRolePermissionsExceeded
+		}
+		return false, nil, fmt.Errorf("error fetching role of user ID %s for restaurant ID %s: %w", actorID, restaurantID, err)
+	}
+	if enum.RoleID(actorRoleID) == enum.RoleOwner {
+		return true, nil, nil
+	}
+
+	permissionsByRole, err := s.getPermissions(ctx, []int32{actorRoleID})
+	if err != nil {
+		return false, nil, err
+	}
+
+	return false, permissionsByRole[actorRoleID], nil
+}
+
+func (s *roleService) getCustomRole(ctx context.Context, restaurantID uuid.UUID, roleID int) (*repository.Role, error) {
+	dbRole, err := s.db.Queries.GetRoleByID(ctx, int32(roleID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("error fetching role %d: %w", roleID, err)
+	}
+	if dbRole.RestaurantID == nil {
+		return nil, ErrRoleReadOnly
+	}
+	if *dbRole.RestaurantID != restaurantID {
+		return nil, ErrRoleNotFound
+	}
+
+	return &dbRole, nil
+}
+
+func (s *roleService) getCustomRoleWithPermissions(ctx context.Context, restaurantID uuid.UUID, roleID int) (*dto.Role, error) {
+	dbRole, err := s.getCustomRole(ctx, restaurantID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissionsByRole, err := s.getPermissions(ctx, []int32{dbRole.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewRole(dbRole, permissionsByRole[dbRole.ID]), nil
+}
+
+func (s *roleService) checkNameAvailable(ctx context.Context, restaurantID uuid.UUID, roleID int, name string) error {
+	nameTaken, err := s.db.Queries.RoleNameTaken(ctx, repository.RoleNameTakenParams{
+		RestaurantID: &restaurantID,
+		Name:         name,
+		ID:           int32(roleID),
+	})
+	if err != nil {
+		return fmt.Errorf("error checking if role name is taken for restaurant ID %s: %w", restaurantID, err)
+	}
+	if nameTaken {
+		return ErrRoleNameTaken
+	}
+
+	return nil
+}
+
+func (s *roleService) getPermissions(ctx context.Context, roleIDs []int32) (map[int32][]enum.Permission, error) {
+	dbPermissions, err := s.db.Queries.GetRolePermissionsByRoleIDs(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role permissions: %w", err)
+	}
+
+	permissionsByRole := make(map[int32][]enum.Permission)
+	for _, p := range dbPermissions {
+		permissionsByRole[p.RoleID] = append(permissionsByRole[p.RoleID], enum.Permission(p.Permission))
+	}
+	return permissionsByRole, nil
+}
+
+func (s *roleService) setPermissions(ctx context.Context, q *repository.Queries, roleID int32, permissions []enum.Permission) error {
+	values := make([]string, len(permissions))
+	for i, permission := range permissions {
+		values[i] = string(permission)
+	}
+
+	err := q.AddRolePermissions(ctx, repository.AddRolePermissionsParams{
+		RoleID:      roleID,
+		Permissions: values,
+	})
+	if err != nil {
+		return fmt.Errorf("error setting permissions of role %d: %w", roleID, err)
+	}
+
+	return nil
+}
+
+// normalizePermissions rejects permissions outside of the catalogue and drops duplicates.
+func normalizePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	normalized := make([]enum.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !slices.Contains(permissionCatalogue, permission) {
+			return nil, ErrUnknownPermission
+		}
+		if !slices.Contains(normalized, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
@@ -8,34 +8,85 @@ import (
 )
 
 type Services struct {
-	AuthService           AuthService
-	GoogleService         GoogleService
-	MailerService         MailerService
-	MenuService           MenuService
-	RestaurantService     RestaurantService
-	RestaurantUserService RestaurantUserService
-	TokenService          TokenService
-	UserService           UserService
+	AccountDeletionService        AccountDeletionService
+	APIKeyService                 APIKeyService
+	AuditService                  AuditService
+	AuthService                   AuthService
+	GoogleService                 GoogleService
+	LoginAttemptService           LoginAttemptService
+	MailerService                 MailerService
+	MenuService                   MenuService
+	OIDCService                   OIDCService
+	OpeningHoursService           OpeningHoursService
+	OrganizationService           OrganizationService
+	OwnershipTransferService      OwnershipTransferService
+	PermissionService             PermissionService
+	PlaceSyncService              PlaceSyncService
+	RefreshTokenService           RefreshTokenService
+	RestaurantInviteService       RestaurantInviteService
+	RestaurantService             RestaurantService
+	RestaurantSettingsService     RestaurantSettingsService
+	RestaurantUserService         RestaurantUserService
+	RestaurantVerificationService RestaurantVerificationService
+	RoleService                   RoleService
+	SessionService                SessionService
+	TokenService                  TokenService
+	TwoFactorService              TwoFactorService
+	UserService                   UserService
 }
 
 func New(cfg *config.Container, db *database.DB, cache cache.Cache, mailer mailer.Mailer) *Services {
 	googleSvc := NewGoogleService(cfg.Google)
 	tokenSvc := NewTokenService(cfg.Security, cache)
 	mailerSvc := NewMailerService(cfg.Mailer, mailer)
-	userSvc := NewUserService(cfg.App, db, tokenSvc, mailerSvc)
-	restaurantSvc := NewRestaurantService(db, googleSvc)
-	authSvc := NewAuthService(cfg.Security, cache, userSvc, tokenSvc, restaurantSvc)
-	restaurantUserSvc := NewRestaurantUserService(db)
-	menuSvc := NewMenuService(db)
+	userSvc := NewUserService(cfg.App, db, cache, tokenSvc, mailerSvc)
+	auditSvc := NewAuditService(db)
+	apiKeySvc := NewAPIKeyService(db, auditSvc)
+	openingHoursSvc := NewOpeningHoursService(db, auditSvc)
+	restaurantSvc := NewRestaurantService(db, auditSvc, googleSvc, openingHoursSvc)
+	refreshTokenSvc := NewRefreshTokenService(cfg.Security, cache, tokenSvc)
+	sessionSvc := NewSessionService(cache, tokenSvc, refreshTokenSvc)
+	oidcSvc := NewOIDCService(cfg.OIDC, cache)
+	twoFactorSvc := NewTwoFactorService(cfg.Security, db)
+	loginAttemptSvc := NewLoginAttemptService(cfg.Security, cache, mailerSvc)
+	accountDeletionSvc := NewAccountDeletionService(cfg.Jobs, db, mailerSvc)
+	authSvc := NewAuthService(cfg.Security, cache, userSvc, tokenSvc, restaurantSvc, sessionSvc, refreshTokenSvc, oidcSvc, twoFactorSvc, loginAttemptSvc, accountDeletionSvc)
+	permissionSvc := NewPermissionService(db)
+	roleSvc := NewRoleService(db, auditSvc)
+	restaurantUserSvc := NewRestaurantUserService(db, auditSvc, roleSvc)
+	menuSvc := NewMenuService(db, auditSvc)
+	restaurantVerificationSvc := NewRestaurantVerificationService(db, cache, auditSvc, mailerSvc)
+	ownershipTransferSvc := NewOwnershipTransferService(cfg.App, db, auditSvc, tokenSvc, mailerSvc)
+	placeSyncSvc := NewPlaceSyncService(cfg.Jobs, db, auditSvc, googleSvc)
+	restaurantSettingsSvc := NewRestaurantSettingsService(db, auditSvc)
+	organizationSvc := NewOrganizationService(db, auditSvc)
+	restaurantInviteSvc := NewRestaurantInviteService(cfg.App, db, auditSvc, tokenSvc, mailerSvc, roleSvc)
 
 	return &Services{
-		AuthService:           authSvc,
-		GoogleService:         googleSvc,
-		MailerService:         mailerSvc,
-		MenuService:           menuSvc,
-		RestaurantService:     restaurantSvc,
-		RestaurantUserService: restaurantUserSvc,
-		TokenService:          tokenSvc,
-		UserService:           userSvc,
+		AccountDeletionService:        accountDeletionSvc,
+		APIKeyService:                 apiKeySvc,
+		AuditService:                  auditSvc,
+		AuthService:                   authSvc,
+		GoogleService:                 googleSvc,
+		LoginAttemptService:           loginAttemptSvc,
+		MailerService:                 mailerSvc,
+		MenuService:                   menuSvc,
+		OIDCService:                   oidcSvc,
+		OpeningHoursService:           openingHoursSvc,
+		OrganizationService:           organizationSvc,
+		OwnershipTransferService:      ownershipTransferSvc,
+		PermissionService:             permissionSvc,
+		PlaceSyncService:              placeSyncSvc,
+		RefreshTokenService:           refreshTokenSvc,
+		RestaurantInviteService:       restaurantInviteSvc,
+		RestaurantService:             restaurantSvc,
+		RestaurantSettingsService:     restaurantSettingsSvc,
+		RestaurantUserService:         restaurantUserSvc,
+		RestaurantVerificationService: restaurantVerificationSvc,
+		RoleService:                   roleSvc,
+		SessionService:                sessionSvc,
+		TokenService:                  tokenSvc,
+		TwoFactorService:              twoFactorSvc,
+		UserService:                   userSvc,
 	}
 }
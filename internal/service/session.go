@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/internal/cache"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/keys"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionService keeps the device details of every auth OAT, sessions live as long as their OAT.
+type SessionService interface {
+	Track(ctx context.Context, oat string, client *dto.SessionClient) error
+	GetByUserID(ctx context.Context, userID uuid.UUID, currentOAT string) ([]*dto.Session, error)
+	Revoke(ctx context.Context, userID uuid.UUID, sessionID string) error
+	Delete(ctx context.Context, oat string) error
+}
+
+type sessionService struct {
+	cache           cache.Cache
+	refreshTokenSvc RefreshTokenService
+	tokenSvc        TokenService
+}
+
+func NewSessionService(cache cache.Cache, tokenSvc TokenService, refreshTokenSvc RefreshTokenService) *sessionService {
+	return &sessionService{
+		cache:           cache,
+		refreshTokenSvc: refreshTokenSvc,
+		tokenSvc:        tokenSvc,
+	}
+}
+
+// Track records the session on its first call and refreshes its last-seen time and TTL afterwards.
+func (s *sessionService) Track(ctx context.Context, oat string, client *dto.SessionClient) error {
+	now := time.Now().UTC()
+
+	session, err := s.get(ctx, oat)
+	if err != nil {
+		if !errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+		session = &dto.Session{
+			ID:         newSessionID(oat),
+			Device:     deviceName(client.ClientType, client.UserAgent),
+			ClientType: client.ClientType,
+			UserAgent:  client.UserAgent,
+			CreatedAt:  now,
+		}
+	}
+
+	session.IP = client.IP
+	session.LastSeenAt = now
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("error marshaling session: %w", err)
+	}
+
+	return s.cache.Set(ctx, cache.GenerateKey(string(keys.Session), oat), data, keys.AuthTokenDuration)
+}
+
+// GetByUserID lists the active sessions of the user, most recently used first.
+func (s *sessionService) GetByUserID(ctx context.Context, userID uuid.UUID, currentOAT string) ([]*dto.Session, error) {
+	oats, err := s.tokenSvc.GetOATs(ctx, keys.AuthToken, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*dto.Session, 0, len(oats))
+	for _, oat := range oats {
+		session, err := s.get(ctx, oat)
+		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		session.IsCurrent = oat == currentOAT
+		sessions = append(sessions, session)
+	}
+
+	slices.SortFunc(sessions, func(a, b *dto.Session) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Revoke signs the session out, the ID is never the OAT itself so listing sessions does not leak tokens.
+func (s *sessionService) Revoke(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	oats, err := s.tokenSvc.GetOATs(ctx, keys.AuthToken, userID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, oat := range oats {
+		if newSessionID(oat) != sessionID {
+			continue
+		}
+		if err := s.tokenSvc.RevokeOAT(ctx, keys.AuthToken, userID.String(), oat); err != nil {
+			return err
+		}
+		if err := s.refreshTokenSvc.RevokeByOAT(ctx, userID.String(), oat); err != nil {
+			return err
+		}
+		return s.Delete(ctx, oat)
+	}
+
+	return ErrSessionNotFound
+}
+
+func (s *sessionService) Delete(ctx context.Context, oat string) error {
+	return s.cache.Delete(ctx, cache.GenerateKey(string(keys.Session), oat))
+}
+
+func (s *sessionService) get(ctx context.Context, oat string) (*dto.Session, error) {
+	data, err := s.cache.Get(ctx, cache.GenerateKey(string(keys.Session), oat))
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var session dto.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("error unmarshaling session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func newSessionID(oat string) string {
+	sum := sha256.Sum256([]byte(oat))
+	return hex.EncodeToString(sum[:16])
+}
+
+// deviceName builds a readable name such as "Chrome on Windows" from the client details.
+func deviceName(clientType, userAgent string) string {
+	var platform string
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"), strings.Contains(userAgent, "iOS"):
+		platform = "iOS"
+	case strings.Contains(userAgent, "Android"):
+		platform = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		platform = "Windows"
+	case strings.Contains(userAgent, "Mac OS X"), strings.Contains(userAgent, "Macintosh"):
+		platform = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		platform = "Linux"
+	}
+
+	var client string
+	switch {
+	case clientType == "mobile":
+		client = "Mobile app"
+	case strings.Contains(userAgent, "Edg/"):
+		client = "Edge"
+	case strings.Contains(userAgent, "Firefox/"):
+		client = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		client = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		client = "Safari"
+	}
+
+	switch {
+	case client != "" && platform != "":
+		return client + " on " + platform
+	case client != "":
+		return client
+	case platform != "":
+		return platform + " device"
+	default:
+		return "Unknown device"
+	}
+}
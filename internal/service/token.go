@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,6 +19,9 @@ var ErrInvalidToken = errors.New("invalid or expired token")
 type TokenService interface {
 	GenerateOAT(ctx context.Context, keyPrefix keys.OAT, data string, ttl time.Duration) (string, error)
 	VerifyOAT(ctx context.Context, keyPrefix keys.OAT, encodedOAT string) (string, error)
+	GetOATs(ctx context.Context, keyPrefix keys.OAT, data string) ([]string, error)
+	RevokeOAT(ctx context.Context, keyPrefix keys.OAT, data, oat string) error
+	RevokeOATs(ctx context.Context, keyPrefix keys.OAT, data string, except ...string) error
 	GenerateSPT(ctx context.Context, keyPrefix keys.SPT, data string, ttl time.Duration) (string, error)
 	VerifySPT(ctx context.Context, keyPrefix keys.SPT, encodedSPT string) (string, error)
 	RevokeSPT(ctx context.Context, keyPrefix keys.SPT, encodedSPT string) error
@@ -46,6 +50,11 @@ func (s *tokenService) GenerateOAT(ctx context.Context, keyPrefix keys.OAT, data
 		return "", err
 	}
 
+	err = s.cache.AddToSet(ctx, oatIndexKey(keyPrefix, data), oat, ttl)
+	if err != nil {
+		return "", err
+	}
+
 	signature := security.SignString(oat, s.cfg.OATSecret)
 	signedOAT := fmt.Sprintf("%s.%s", oat, signature)
 
@@ -80,6 +89,70 @@ func (s *tokenService) VerifyOAT(ctx context.Context, keyPrefix keys.OAT, encode
 	return string(data), nil
 }
 
+// GetOATs returns the raw OATs still valid for data, expired ones are dropped from the index.
+func (s *tokenService) GetOATs(ctx context.Context, keyPrefix keys.OAT, data string) ([]string, error) {
+	indexKey := oatIndexKey(keyPrefix, data)
+	indexed, err := s.cache.GetSet(ctx, indexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	oats := make([]string, 0, len(indexed))
+	expired := make([]string, 0)
+	for _, oat := range indexed {
+		_, err := s.cache.Get(ctx, cache.GenerateKey(string(keyPrefix), oat))
+		if err != nil {
+			if errors.Is(err, cache.ErrCacheNotFound) {
+				expired = append(expired, oat)
+				continue
+			}
+			return nil, err
+		}
+		oats = append(oats, oat)
+	}
+
+	if err := s.cache.RemoveFromSet(ctx, indexKey, expired...); err != nil {
+		return nil, err
+	}
+
+	return oats, nil
+}
+
+func (s *tokenService) RevokeOAT(ctx context.Context, keyPrefix keys.OAT, data, oat string) error {
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keyPrefix), oat)); err != nil {
+		return err
+	}
+
+	return s.cache.RemoveFromSet(ctx, oatIndexKey(keyPrefix, data), oat)
+}
+
+// RevokeOATs revokes every OAT issued for data, the raw OATs listed in except are kept.
+func (s *tokenService) RevokeOATs(ctx context.Context, keyPrefix keys.OAT, data string, except ...string) error {
+	indexKey := oatIndexKey(keyPrefix, data)
+	oats, err := s.cache.GetSet(ctx, indexKey)
+	if err != nil {
+		return err
+	}
+
+	revoked := make([]string, 0, len(oats))
+	for _, oat := range oats {
+		if slices.Contains(except, oat) {
+			continue
+		}
+		if err := s.cache.Delete(ctx, cache.GenerateKey(string(keyPrefix), oat)); err != nil {
+			return err
+		}
+		revoked = append(revoked, oat)
+	}
+
+	return s.cache.RemoveFromSet(ctx, indexKey, revoked...)
+}
+
+// oatIndexKey is the key of the set holding the raw OATs issued for data.
+func oatIndexKey(keyPrefix keys.OAT, data string) string {
+	return cache.GenerateKey(string(keyPrefix)+"_index", data)
+}
+
 func (s *tokenService) GenerateSPT(ctx context.Context, keyPrefix keys.SPT, data string, ttl time.Duration) (string, error) {
 	// Format: <user_id>.<signature>
 	signature := security.SignString(data, s.cfg.SPTSecret)
@@ -124,3 +197,14 @@ func (s *tokenService) VerifySPT(ctx context.Context, keyPrefix keys.SPT, encode
 func (s *tokenService) RevokeSPT(ctx context.Context, keyPrefix keys.SPT, data string) error {
 	return s.cache.Delete(ctx, cache.GenerateKey(string(keyPrefix), data))
 }
+
+// decodeOAT returns the raw OAT, the one used in cache keys, from the encoded OAT handed to clients.
+func decodeOAT(encodedOAT string) (string, error) {
+	decodedOAT, err := security.DecodeTokenURLSafe(encodedOAT)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	oat, _, _ := strings.Cut(decodedOAT, ".")
+	return oat, nil
+}
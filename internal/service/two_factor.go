@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
+	"github.com/memsbdm/restaurant-api/internal/dto"
+	"github.com/memsbdm/restaurant-api/pkg/security"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication enrollment not found")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+const (
+	totpIssuer         = "Restaurant API"
+	recoveryCodesCount = 10
+)
+
+type TwoFactorService interface {
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	Enroll(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollment, error)
+	Confirm(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodes, error)
+	Disable(ctx context.Context, userID uuid.UUID, password string) error
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+type twoFactorService struct {
+	cfg *config.Security
+	db  *database.DB
+}
+
+func NewTwoFactorService(cfg *config.Security, db *database.DB) *twoFactorService {
+	return &twoFactorService{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+func (s *twoFactorService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	totp, err := s.db.Queries.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error fetching TOTP of user ID %s: %w", userID, err)
+	}
+
+	return totp.ConfirmedAt != nil, nil
+}
+
+// Enroll generates a new secret, it only protects the account once confirmed with a first code.
+// Enrolling again before confirming replaces the pending secret.
+func (s *twoFactorService) Enroll(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollment, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Queries.UpsertUserTOTP(ctx, repository.UpsertUserTOTPParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving TOTP of user ID %s: %w", userID, err)
+	}
+
+	return &dto.TOTPEnrollment{
+		Secret:     secret,
+		OTPAuthURI: otpAuthURI(secret, dbUser.Email),
+	}, nil
+}
+
+// Confirm enables two-factor authentication and returns the recovery codes, they are only shown once.
+func (s *twoFactorService) Confirm(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodes, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	totp, err := qtx.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, fmt.Errorf("error fetching TOTP of user ID %s: %w", userID, err)
+	}
+	if totp.ConfirmedAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := security.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	err = qtx.ConfirmUserTOTP(ctx, repository.ConfirmUserTOTPParams{
+		UserID:       userID,
+		LastUsedStep: &step,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error confirming TOTP of user ID %s: %w", userID, err)
+	}
+
+	recoveryCodes, err := s.createRecoveryCodes(ctx, qtx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID uuid.UUID, password string) error {
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	if err := security.ComparePassword(dbUser.Password, password); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	if err := qtx.DeleteUserTOTP(ctx, userID); err != nil {
+		return fmt.Errorf("error deleting TOTP of user ID %s: %w", userID, err)
+	}
+
+	if err := qtx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return fmt.Errorf("error deleting recovery codes of user ID %s: %w", userID, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Verify accepts a TOTP code or an unused recovery code. A TOTP code is refused once used.
+func (s *twoFactorService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	totp, err := s.db.Queries.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTwoFactorNotEnabled
+		}
+		return fmt.Errorf("error fetching TOTP of user ID %s: %w", userID, err)
+	}
+	if totp.ConfirmedAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := security.ValidateTOTP(totp.Secret, code, time.Now()); ok {
+		used, err := s.db.Queries.UseUserTOTPStep(ctx, repository.UseUserTOTPStepParams{
+			UserID:       userID,
+			LastUsedStep: &step,
+		})
+		if err != nil {
+			return fmt.Errorf("error saving TOTP step of user ID %s: %w", userID, err)
+		}
+		if used == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := s.db.Queries.UseUserRecoveryCode(ctx, repository.UseUserRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: s.hashRecoveryCode(code),
+	})
+	if err != nil {
+		return fmt.Errorf("error using recovery code of user ID %s: %w", userID, err)
+	}
+	if used == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (s *twoFactorService) createRecoveryCodes(ctx context.Context, q *repository.Queries, userID uuid.UUID) (*dto.RecoveryCodes, error) {
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		code, err := security.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = s.hashRecoveryCode(code)
+	}
+
+	err := q.CreateUserRecoveryCodes(ctx, repository.CreateUserRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: hashes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating recovery codes of user ID %s: %w", userID, err)
+	}
+
+	return &dto.RecoveryCodes{Codes: codes}, nil
+}
+
+// hashRecoveryCode ignores case and separators. The HMAC key stays out of the database,
+// so a dump of the hashes cannot be brute-forced offline.
+func (s *twoFactorService) hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	h := hmac.New(sha256.New, s.cfg.RecoveryCodeSecret)
+	h.Write([]byte(normalized))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func otpAuthURI(secret, email string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", totpIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(security.TOTPDigits))
+	params.Set("period", fmt.Sprint(security.TOTPPeriod))
+
+	label := url.PathEscape(totpIssuer + ":" + email)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
@@ -2,14 +2,23 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/memsbdm/restaurant-api/config"
+	"github.com/memsbdm/restaurant-api/internal/cache"
 	"github.com/memsbdm/restaurant-api/internal/database"
+	"github.com/memsbdm/restaurant-api/internal/database/repository"
 	"github.com/memsbdm/restaurant-api/internal/dto"
 	"github.com/memsbdm/restaurant-api/internal/mailer"
+	"github.com/memsbdm/restaurant-api/internal/validation"
 	"github.com/memsbdm/restaurant-api/pkg/keys"
 	"github.com/memsbdm/restaurant-api/pkg/security"
 )
@@ -17,29 +26,41 @@ import (
 var (
 	ErrEmailConflict        = errors.New("email already taken")
 	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrIncorrectPassword    = errors.New("current password is incorrect")
+	ErrSameEmail            = errors.New("new email is the same as the current one")
 )
 
 type UserService interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*dto.User, error)
 	GetByEmail(ctx context.Context, email string) (*dto.User, error)
 	Create(ctx context.Context, user *dto.CreateUser) (*dto.User, error)
-	Update(ctx context.Context, user *dto.User) (*dto.User, error)
+	Update(ctx context.Context, userID uuid.UUID, user *dto.UpdateUser) (*dto.User, error)
+	GetProfile(ctx context.Context, userID uuid.UUID) (*dto.UserProfile, error)
 	SendVerificationEmail(ctx context.Context, user *dto.User) error
 	VerifyEmail(ctx context.Context, token string) (*dto.User, error)
 	ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+	SendPasswordResetEmail(ctx context.Context, user *dto.User) error
+	ResetPassword(ctx context.Context, token, password string) (uuid.UUID, error)
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
+	GetOrCreateByIdentity(ctx context.Context, identity *dto.OIDCIdentity) (*dto.User, error)
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, email string) error
+	ConfirmEmailChange(ctx context.Context, token string) (*dto.User, error)
+	CancelEmailChange(ctx context.Context, token string) error
 }
 
 type userService struct {
 	cfg       *config.App
 	db        *database.DB
+	cache     cache.Cache
 	mailerSvc MailerService
 	tokenSvc  TokenService
 }
 
-func NewUserService(cfg *config.App, db *database.DB, tokenSvc TokenService, mailerSvc MailerService) *userService {
+func NewUserService(cfg *config.App, db *database.DB, cache cache.Cache, tokenSvc TokenService, mailerSvc MailerService) *userService {
 	return &userService{
 		cfg:       cfg,
 		db:        db,
+		cache:     cache,
 		mailerSvc: mailerSvc,
 		tokenSvc:  tokenSvc,
 	}
@@ -88,15 +109,38 @@ func (s *userService) Create(ctx context.Context, user *dto.CreateUser) (*dto.Us
 	return dto.NewUser(&dbUser), nil
 }
 
-func (s *userService) Update(ctx context.Context, user *dto.User) (*dto.User, error) {
-	dbUser, err := s.db.Queries.UpdateUser(ctx, user.ToUpdateParams())
+func (s *userService) Update(ctx context.Context, userID uuid.UUID, user *dto.UpdateUser) (*dto.User, error) {
+	dbUser, err := s.db.Queries.UpdateUser(ctx, user.ToParams(userID))
 	if err != nil {
-		return nil, fmt.Errorf("error updating user: %w", err)
+		return nil, fmt.Errorf("error updating user ID %s: %w", userID, err)
 	}
 
 	return dto.NewUser(&dbUser), nil
 }
 
+// GetProfile returns the user with every restaurant they can access, directly or through an organization.
+func (s *userService) GetProfile(ctx context.Context, userID uuid.UUID) (*dto.UserProfile, error) {
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	dbRestaurants, err := s.db.Queries.GetUserRestaurantRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching restaurants for user ID %s: %w", userID, err)
+	}
+
+	restaurants := make([]*dto.UserRestaurant, len(dbRestaurants))
+	for i := range dbRestaurants {
+		restaurants[i] = dto.NewUserRestaurant(&dbRestaurants[i])
+	}
+
+	return &dto.UserProfile{
+		User:        dto.NewUser(&dbUser),
+		Restaurants: restaurants,
+	}, nil
+}
+
 func (s *userService) SendVerificationEmail(ctx context.Context, user *dto.User) error {
 	spt, err := s.tokenSvc.GenerateSPT(ctx, keys.EmailVerification, user.ID.String(), keys.EmailVerificationTokenDuration)
 	if err != nil {
@@ -126,14 +170,10 @@ func (s *userService) VerifyEmail(ctx context.Context, token string) (*dto.User,
 	}
 
 	userID := decodedToken
-	dbUser, err := s.db.Queries.GetUserByID(ctx, uuid.MustParse(userID))
-	if err != nil {
-		return nil, fmt.Errorf("error fetching user by ID %s: %w", userID, err)
-	}
-
-	dbUser.IsEmailVerified = true
-	dbUserDTO := dto.NewUser(&dbUser)
-	updatedUser, err := s.db.Queries.UpdateUser(ctx, dbUserDTO.ToUpdateParams())
+	isEmailVerified := true
+	updatedUser, err := s.db.Queries.UpdateUser(ctx, dto.UpdateUser{
+		IsEmailVerified: &isEmailVerified,
+	}.ToParams(uuid.MustParse(userID)))
 	if err != nil {
 		return nil, fmt.Errorf("error updating user: %w", err)
 	}
@@ -159,3 +199,378 @@ func (s *userService) ResendVerificationEmail(ctx context.Context, userID uuid.U
 	user := dto.NewUser(&dbUser)
 	return s.SendVerificationEmail(ctx, user)
 }
+
+// SendPasswordResetEmail emails a reset token bound to the current password hash,
+// the token stops working as soon as the password changes.
+func (s *userService) SendPasswordResetEmail(ctx context.Context, user *dto.User) error {
+	data := fmt.Sprintf("%s:%s", user.ID, passwordFingerprint(user.Password))
+	spt, err := s.tokenSvc.GenerateSPT(ctx, keys.PasswordReset, data, keys.PasswordResetTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	emailtmpl, err := s.mailerSvc.RenderTemplate("password_reset.tmpl", map[string]any{
+		"User":  user,
+		"Token": spt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body:    emailtmpl,
+	})
+}
+
+// ResetPassword replaces the password of the user the token was issued for and returns its ID.
+func (s *userService) ResetPassword(ctx context.Context, token, password string) (uuid.UUID, error) {
+	data, err := s.tokenSvc.VerifySPT(ctx, keys.PasswordReset, token)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userIDStr, fingerprint, ok := strings.Cut(data, ":")
+	if !ok {
+		return uuid.Nil, ErrInvalidToken
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrInvalidToken
+		}
+		return uuid.Nil, fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(fingerprint), []byte(passwordFingerprint(dbUser.Password))) != 1 {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	hashedPassword, err := security.HashPassword(password)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	err = s.db.Queries.UpdateUserPassword(ctx, repository.UpdateUserPasswordParams{
+		Password: hashedPassword,
+		ID:       userID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error updating password of user ID %s: %w", userID, err)
+	}
+
+	if err := s.tokenSvc.RevokeSPT(ctx, keys.PasswordReset, data); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+func (s *userService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	if err := security.ComparePassword(dbUser.Password, currentPassword); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	hashedPassword, err := security.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Queries.UpdateUserPassword(ctx, repository.UpdateUserPasswordParams{
+		Password: hashedPassword,
+		ID:       userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating password of user ID %s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// GetOrCreateByIdentity returns the user linked to the identity. An unlinked identity is linked to the verified user
+// owning its email, or to a new verified user without a usable password when there is none.
+func (s *userService) GetOrCreateByIdentity(ctx context.Context, identity *dto.OIDCIdentity) (*dto.User, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.db.Queries.WithTx(tx)
+
+	dbUser, err := qtx.GetUserByIdentity(ctx, repository.GetUserByIdentityParams{
+		Provider: string(identity.Provider),
+		Subject:  identity.Subject,
+	})
+	if err == nil {
+		return dto.NewUser(&dbUser), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error fetching user by %s identity: %w", identity.Provider, err)
+	}
+
+	dbUser, err = qtx.GetVerifiedUserByEmail(ctx, identity.Email)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error fetching user by email %s: %w", identity.Email, err)
+		}
+
+		// Users signing up with a provider can still set a password later through the reset flow
+		password, err := security.GenerateRandomString(32)
+		if err != nil {
+			return nil, err
+		}
+		hashedPassword, err := security.HashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+
+		dbUser, err = qtx.CreateUser(ctx, dto.CreateUser{
+			Name:     identityName(identity),
+			Email:    identity.Email,
+			Password: hashedPassword,
+		}.ToParams())
+		if err != nil {
+			return nil, fmt.Errorf("error creating user: %w", err)
+		}
+
+		isEmailVerified := true
+		dbUser, err = qtx.UpdateUser(ctx, dto.UpdateUser{
+			IsEmailVerified: &isEmailVerified,
+			AvatarURL:       identity.AvatarURL,
+		}.ToParams(dbUser.ID))
+		if err != nil {
+			return nil, fmt.Errorf("error updating user: %w", err)
+		}
+	}
+
+	err = qtx.CreateUserIdentity(ctx, repository.CreateUserIdentityParams{
+		UserID:   dbUser.ID,
+		Provider: string(identity.Provider),
+		Subject:  identity.Subject,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error linking %s identity to user ID %s: %w", identity.Provider, dbUser.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return dto.NewUser(&dbUser), nil
+}
+
+// emailChangeRequest is the pending change of a user, the nonce ties it to the tokens sent for it.
+type emailChangeRequest struct {
+	Email string `json:"email"`
+	Nonce string `json:"nonce"`
+}
+
+// RequestEmailChange sends a confirmation token to the new email and a cancellation link to the current one.
+// The current email stays active until the change is confirmed, a new request replaces the pending one.
+func (s *userService) RequestEmailChange(ctx context.Context, userID uuid.UUID, email string) error {
+	dbUser, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error fetching user by ID %s: %w", userID, err)
+	}
+
+	if strings.EqualFold(dbUser.Email, email) {
+		return ErrSameEmail
+	}
+
+	emailTaken, err := s.db.Queries.UserEmailTaken(ctx, email)
+	if err != nil {
+		return fmt.Errorf("error checking if email %s is taken: %w", email, err)
+	}
+	if emailTaken {
+		return ErrEmailConflict
+	}
+
+	nonce, err := security.GenerateRandomString(18)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(emailChangeRequest{
+		Email: email,
+		Nonce: nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling email change request: %w", err)
+	}
+
+	err = s.cache.Set(ctx, cache.GenerateKey(string(keys.EmailChangeRequest), userID), data, keys.EmailChangeTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	// Email addresses contain dots which SPTs use as separator, the tokens only carry the user ID and the nonce
+	tokenData := fmt.Sprintf("%s:%s", userID, nonce)
+	confirmToken, err := s.tokenSvc.GenerateSPT(ctx, keys.EmailChange, tokenData, keys.EmailChangeTokenDuration)
+	if err != nil {
+		return err
+	}
+	cancelToken, err := s.tokenSvc.GenerateSPT(ctx, keys.EmailChangeCancel, tokenData, keys.EmailChangeTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	user := dto.NewUser(&dbUser)
+	confirmtmpl, err := s.mailerSvc.RenderTemplate("email_change.tmpl", map[string]any{
+		"Host":  s.cfg.Host,
+		"User":  user,
+		"Email": email,
+		"Token": confirmToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{email},
+		Subject: "Confirm your new email",
+		Body:    confirmtmpl,
+	})
+	if err != nil {
+		return err
+	}
+
+	noticetmpl, err := s.mailerSvc.RenderTemplate("email_change_notice.tmpl", map[string]any{
+		"Host":  s.cfg.Host,
+		"User":  user,
+		"Email": email,
+		"Token": cancelToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailerSvc.Send(&mailer.Mail{
+		To:      []string{user.Email},
+		Subject: "Your email is about to change",
+		Body:    noticetmpl,
+	})
+}
+
+// ConfirmEmailChange switches the user to the new email, which may have been taken since the request.
+func (s *userService) ConfirmEmailChange(ctx context.Context, token string) (*dto.User, error) {
+	tokenData, err := s.tokenSvc.VerifySPT(ctx, keys.EmailChange, token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, request, err := s.getEmailChangeRequest(ctx, tokenData)
+	if err != nil {
+		return nil, err
+	}
+
+	emailTaken, err := s.db.Queries.UserEmailTaken(ctx, request.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if email %s is taken: %w", request.Email, err)
+	}
+	if emailTaken {
+		return nil, ErrEmailConflict
+	}
+
+	isEmailVerified := true
+	updatedUser, err := s.db.Queries.UpdateUser(ctx, dto.UpdateUser{
+		Email:           &request.Email,
+		IsEmailVerified: &isEmailVerified,
+	}.ToParams(userID))
+	if err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	if err := s.clearEmailChangeRequest(ctx, userID, tokenData); err != nil {
+		return nil, err
+	}
+
+	return dto.NewUser(&updatedUser), nil
+}
+
+func (s *userService) CancelEmailChange(ctx context.Context, token string) error {
+	tokenData, err := s.tokenSvc.VerifySPT(ctx, keys.EmailChangeCancel, token)
+	if err != nil {
+		return err
+	}
+
+	userID, _, err := s.getEmailChangeRequest(ctx, tokenData)
+	if err != nil {
+		return err
+	}
+
+	return s.clearEmailChangeRequest(ctx, userID, tokenData)
+}
+
+// getEmailChangeRequest returns the pending request the token data was issued for, tokens of replaced requests are refused.
+func (s *userService) getEmailChangeRequest(ctx context.Context, tokenData string) (uuid.UUID, *emailChangeRequest, error) {
+	userIDStr, nonce, ok := strings.Cut(tokenData, ":")
+	if !ok {
+		return uuid.Nil, nil, ErrInvalidToken
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, nil, ErrInvalidToken
+	}
+
+	data, err := s.cache.Get(ctx, cache.GenerateKey(string(keys.EmailChangeRequest), userID))
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotFound) {
+			return uuid.Nil, nil, ErrInvalidToken
+		}
+		return uuid.Nil, nil, err
+	}
+
+	var request emailChangeRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("error unmarshaling email change request: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(request.Nonce), []byte(nonce)) != 1 {
+		return uuid.Nil, nil, ErrInvalidToken
+	}
+
+	return userID, &request, nil
+}
+
+func (s *userService) clearEmailChangeRequest(ctx context.Context, userID uuid.UUID, tokenData string) error {
+	if err := s.cache.Delete(ctx, cache.GenerateKey(string(keys.EmailChangeRequest), userID)); err != nil {
+		return err
+	}
+
+	if err := s.tokenSvc.RevokeSPT(ctx, keys.EmailChange, tokenData); err != nil {
+		return err
+	}
+
+	return s.tokenSvc.RevokeSPT(ctx, keys.EmailChangeCancel, tokenData)
+}
+
+// identityName falls back to the local part of the email when the provider shares no name.
+func identityName(identity *dto.OIDCIdentity) string {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	runes := []rune(name)
+	if len(runes) > validation.UserNameMaxLength {
+		return string(runes[:validation.UserNameMaxLength])
+	}
+	return name
+}
+
+// passwordFingerprint identifies the current password hash without exposing it in tokens.
+func passwordFingerprint(hashedPassword string) string {
+	sum := sha256.Sum256([]byte(hashedPassword))
+	return hex.EncodeToString(sum[:8])
+}
@@ -12,34 +12,149 @@ const (
 
 // Required
 var (
-	ErrInvalidEmail     = errors.New("invalid email format")
-	ErrNameRequired     = errors.New("name is required")
-	ErrEmailRequired    = errors.New("email is required")
-	ErrPasswordRequired = errors.New("password is required")
+	ErrInvalidEmail            = errors.New("invalid email format")
+	ErrNameRequired            = errors.New("name is required")
+	ErrEmailRequired           = errors.New("email is required")
+	ErrPasswordRequired        = errors.New("password is required")
+	ErrTimezoneRequired        = errors.New("timezone is required")
+	ErrDateRequired            = errors.New("date is required")
+	ErrCodeRequired            = errors.New("code is required")
+	ErrBusinessEmailRequired   = errors.New("business email is required to verify by email")
+	ErrDocumentRequired        = errors.New("document URL is required to verify by document")
+	ErrUserIDRequired          = errors.New("user ID is required")
+	ErrLatitudeRequired        = errors.New("lat is required")
+	ErrLongitudeRequired       = errors.New("lng is required")
+	ErrAddressRequired         = errors.New("address is required")
+	ErrRestaurantIDRequired    = errors.New("restaurant ID is required")
+	ErrPriceRequired           = errors.New("price is required")
+	ErrTokenRequired           = errors.New("token is required")
+	ErrAvailabilityRequired    = errors.New("is_available is required")
+	ErrPermissionsRequired     = errors.New("permissions are required")
+	ErrCurrentPasswordRequired = errors.New("current password is required")
+	ErrRefreshTokenRequired    = errors.New("refresh token is required")
+	ErrScopesRequired          = errors.New("at least one scope is required")
+)
+
+// Format
+var (
+	ErrInvalidTimezone           = errors.New("invalid timezone, expected an IANA name such as Europe/Paris")
+	ErrInvalidDate               = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidClock              = errors.New("invalid time, expected HH:MM")
+	ErrInvalidVerificationMethod = errors.New("invalid verification method, expected EMAIL or DOCUMENT")
+	ErrInvalidUserID             = errors.New("invalid user ID")
+	ErrInvalidLatitude           = errors.New("invalid latitude, expected a value between -90 and 90")
+	ErrInvalidLongitude          = errors.New("invalid longitude, expected a value between -180 and 180")
+	ErrInvalidCurrency           = errors.New("invalid currency, expected an ISO 4217 code such as EUR")
+	ErrInvalidLocale             = errors.New("invalid locale, expected a language tag such as fr or en-US")
+	ErrInvalidRestaurantID       = errors.New("invalid restaurant ID")
+	ErrInvalidRole               = errors.New("invalid role, expected 1 (owner) or 2 (manager)")
+	ErrInvalidRestaurantRole     = errors.New("invalid role ID")
+	ErrInvalidAuditEntity        = errors.New("invalid entity type")
+	ErrInvalidAvatarURL          = errors.New("invalid avatar URL")
+	ErrInvalidPage               = errors.New("invalid page, expected a value between 1 and 1000")
 )
 
 // Min
 var (
 	ErrPasswordTooShort = fmt.Errorf("password should contain at least %d characters", UserPasswordMinLength)
 	ErrUserNameTooLong  = fmt.Errorf("name should contain at most %d characters", UserNameMaxLength)
+	ErrRoleNameTooLong  = errors.New("name should contain at most 50 characters")
 )
 
 // errorMessages holds custom error messages for specific validation failures.
 var errorMessages = map[string]error{
 	// Required
-	"registerUserRequest.Name.notblank":     ErrNameRequired,
-	"registerUserRequest.Email.notblank":    ErrEmailRequired,
-	"registerUserRequest.Password.notblank": ErrPasswordRequired,
-	"loginUserRequest.Email.notblank":       ErrEmailRequired,
-	"loginUserRequest.Password.notblank":    ErrEmailRequired,
+	"registerUserRequest.Name.notblank":                              ErrNameRequired,
+	"registerUserRequest.Email.notblank":                             ErrEmailRequired,
+	"registerUserRequest.Password.notblank":                          ErrPasswordRequired,
+	"loginUserRequest.Email.notblank":                                ErrEmailRequired,
+	"loginUserRequest.Password.notblank":                             ErrEmailRequired,
+	"updateOpeningHoursRequest.Timezone.notblank":                    ErrTimezoneRequired,
+	"createOpeningHourExceptionRequest.Date.required":                ErrDateRequired,
+	"confirmRestaurantVerificationRequest.Code.notblank":             ErrCodeRequired,
+	"requestRestaurantVerificationRequest.BusinessEmail.required_if": ErrBusinessEmailRequired,
+	"requestRestaurantVerificationRequest.DocumentURL.required_if":   ErrDocumentRequired,
+	"nominateOwnerRequest.UserID.required":                           ErrUserIDRequired,
+	"updateRestaurantRequest.Name.notblank":                          ErrNameRequired,
+	"updateRestaurantRequest.Address.notblank":                       ErrAddressRequired,
+	"nearbyRestaurantsRequest.Lat.required":                          ErrLatitudeRequired,
+	"nearbyRestaurantsRequest.Lng.required":                          ErrLongitudeRequired,
+	"createOrganizationRequest.Name.notblank":                        ErrNameRequired,
+	"addOrganizationRestaurantRequest.RestaurantID.required":         ErrRestaurantIDRequired,
+	"addOrganizationMemberRequest.Email.notblank":                    ErrEmailRequired,
+	"createSharedMenuRequest.Name.notblank":                          ErrNameRequired,
+	"createCategoryRequest.Name.notblank":                            ErrNameRequired,
+	"createArticleRequest.Name.notblank":                             ErrNameRequired,
+	"setPriceOverrideRequest.Price.required":                         ErrPriceRequired,
+	"setAvailabilityRequest.IsAvailable.required":                    ErrAvailabilityRequired,
+	"createRestaurantInviteRequest.Email.notblank":                   ErrEmailRequired,
+	"acceptRestaurantInviteRequest.Token.notblank":                   ErrTokenRequired,
+	"acceptRestaurantInviteRequest.Name.required_with":               ErrNameRequired,
+	"acceptRestaurantInviteRequest.Password.required_with":           ErrPasswordRequired,
+	"saveRoleRequest.Name.notblank":                                  ErrNameRequired,
+	"saveRoleRequest.Permissions.required":                           ErrPermissionsRequired,
+	"forgotPasswordRequest.Email.notblank":                           ErrEmailRequired,
+	"resetPasswordRequest.Token.notblank":                            ErrTokenRequired,
+	"resetPasswordRequest.Password.notblank":                         ErrPasswordRequired,
+	"changePasswordRequest.CurrentPassword.notblank":                 ErrCurrentPasswordRequired,
+	"changePasswordRequest.NewPassword.notblank":                     ErrPasswordRequired,
+	"refreshTokenRequest.RefreshToken.notblank":                      ErrRefreshTokenRequired,
+	"verifyTwoFactorRequest.ChallengeToken.notblank":                 ErrTokenRequired,
+	"verifyTwoFactorRequest.Code.notblank":                           ErrCodeRequired,
+	"confirmTwoFactorRequest.Code.notblank":                          ErrCodeRequired,
+	"disableTwoFactorRequest.Password.notblank":                      ErrPasswordRequired,
+	"changeEmailRequest.Email.notblank":                              ErrEmailRequired,
+	"updateMeRequest.Name.notblank":                                  ErrNameRequired,
+	"deleteMeRequest.Password.notblank":                              ErrPasswordRequired,
+	"createAPIKeyRequest.Name.notblank":                              ErrNameRequired,
+	"createAPIKeyRequest.Scopes.required":                            ErrScopesRequired,
+	"createAPIKeyRequest.Scopes.min":                                 ErrScopesRequired,
 
 	// Min
-	"registerUserRequest.Password.min": ErrPasswordTooShort,
+	"registerUserRequest.Password.min":           ErrPasswordTooShort,
+	"acceptRestaurantInviteRequest.Password.min": ErrPasswordTooShort,
+	"resetPasswordRequest.Password.min":          ErrPasswordTooShort,
+	"changePasswordRequest.NewPassword.min":      ErrPasswordTooShort,
 
 	// Max
-	"registerUserRequest.Name.max": ErrUserNameTooLong,
+	"registerUserRequest.Name.max":           ErrUserNameTooLong,
+	"acceptRestaurantInviteRequest.Name.max": ErrUserNameTooLong,
+	"saveRoleRequest.Name.max":               ErrRoleNameTooLong,
+	"updateMeRequest.Name.max":               ErrUserNameTooLong,
+	"createAPIKeyRequest.Name.max":           ErrRoleNameTooLong,
 
 	// Email
-	"registerUserRequest.Email.email": ErrInvalidEmail,
-	"loginUserRequest.Email.email":    ErrInvalidEmail,
+	"registerUserRequest.Email.email":                          ErrInvalidEmail,
+	"loginUserRequest.Email.email":                             ErrInvalidEmail,
+	"requestRestaurantVerificationRequest.BusinessEmail.email": ErrInvalidEmail,
+	"addOrganizationMemberRequest.Email.email":                 ErrInvalidEmail,
+	"createRestaurantInviteRequest.Email.email":                ErrInvalidEmail,
+	"forgotPasswordRequest.Email.email":                        ErrInvalidEmail,
+	"changeEmailRequest.Email.email":                           ErrInvalidEmail,
+
+	// Format
+	"updateOpeningHoursRequest.Timezone.timezone":               ErrInvalidTimezone,
+	"createOpeningHourExceptionRequest.Date.datetime":           ErrInvalidDate,
+	"createOpeningHourExceptionRequest.OpensAt.clock":           ErrInvalidClock,
+	"createOpeningHourExceptionRequest.ClosesAt.clock":          ErrInvalidClock,
+	"requestRestaurantVerificationRequest.Method.oneof":         ErrInvalidVerificationMethod,
+	"nominateOwnerRequest.UserID.uuid":                          ErrInvalidUserID,
+	"nearbyRestaurantsRequest.Lat.latitude":                     ErrInvalidLatitude,
+	"nearbyRestaurantsRequest.Lng.longitude":                    ErrInvalidLongitude,
+	"nearbyRestaurantsRequest.Page.min":                         ErrInvalidPage,
+	"nearbyRestaurantsRequest.Page.max":                         ErrInvalidPage,
+	"updateRestaurantSettingsRequest.Timezone.timezone":         ErrInvalidTimezone,
+	"updateRestaurantSettingsRequest.Currency.iso4217":          ErrInvalidCurrency,
+	"updateRestaurantSettingsRequest.Locale.bcp47_language_tag": ErrInvalidLocale,
+	"addOrganizationRestaurantRequest.RestaurantID.uuid":        ErrInvalidRestaurantID,
+	"addOrganizationMemberRequest.RoleID.oneof":                 ErrInvalidRole,
+	"createRestaurantInviteRequest.RoleID.gt":                   ErrInvalidRestaurantRole,
+	"updateMemberRoleRequest.RoleID.gt":                         ErrInvalidRestaurantRole,
+	"createRestaurantInviteRequest.RoleID.lte":                  ErrInvalidRestaurantRole,
+	"updateMemberRoleRequest.RoleID.lte":                        ErrInvalidRestaurantRole,
+	"auditLogRequest.Actor.uuid":                                ErrInvalidUserID,
+	"auditLogRequest.Entity.oneof":                              ErrInvalidAuditEntity,
+	"auditLogRequest.From.datetime":                             ErrInvalidDate,
+	"auditLogRequest.To.datetime":                               ErrInvalidDate,
+	"updateMeRequest.AvatarURL.eq=|url":                         ErrInvalidAvatarURL,
 }
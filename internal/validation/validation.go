@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -19,6 +20,8 @@ const (
 var (
 	Validate *validator.Validate
 	once     sync.Once
+
+	clockRegexp = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
 )
 
 func init() {
@@ -27,6 +30,9 @@ func init() {
 		if err := Validate.RegisterValidation("notblank", notBlank); err != nil {
 			log.Printf("failed to register notblank validation: %v", err)
 		}
+		if err := Validate.RegisterValidation("clock", clock); err != nil {
+			log.Printf("failed to register clock validation: %v", err)
+		}
 	})
 }
 
@@ -35,6 +41,11 @@ func notBlank(fl validator.FieldLevel) bool {
 	return len(strings.TrimSpace(fl.Field().String())) > 0
 }
 
+// clock validates that the string is a time of day formatted as HH:MM, 24:00 included.
+func clock(fl validator.FieldLevel) bool {
+	return clockRegexp.MatchString(fl.Field().String())
+}
+
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -57,7 +68,11 @@ func ValidateRequest(w http.ResponseWriter, r *http.Request, payload any) ([]Val
 		return nil, err
 	}
 
-	// Validate payload
+	return ValidateStruct(payload), nil
+}
+
+// ValidateStruct verifies an already decoded payload, e.g. one built from query parameters.
+func ValidateStruct(payload any) []ValidationError {
 	var errs []ValidationError
 	if err := Validate.Struct(payload); err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
@@ -75,5 +90,5 @@ func ValidateRequest(w http.ResponseWriter, r *http.Request, payload any) ([]Val
 			errs = append(errs, validationErr)
 		}
 	}
-	return errs, nil
+	return errs
 }
@@ -8,12 +8,42 @@ type SPT string
 // Opaque Access Token
 type OAT string
 
+// One-Time Code
+type OTC string
+
+// Cached record
+type Record string
+
 const (
-	AuthToken         OAT = "access_token"
-	EmailVerification SPT = "email_verification"
+	AuthToken                  OAT    = "access_token"
+	EmailChange                SPT    = "email_change"
+	EmailChangeCancel          SPT    = "email_change_cancel"
+	EmailChangeRequest         Record = "email_change_request"
+	EmailVerification          SPT    = "email_verification"
+	LoginFailures              Record = "login_failures"
+	LoginLockout               Record = "login_lockout"
+	LoginLockouts              Record = "login_lockouts"
+	OIDCState                  Record = "oidc_state"
+	OwnershipTransfer          SPT    = "ownership_transfer"
+	PasswordReset              SPT    = "password_reset"
+	RefreshToken               OAT    = "refresh_token"
+	RefreshTokenFamily         Record = "refresh_token_family"
+	RefreshTokenUsed           Record = "refresh_token_used"
+	RestaurantInvite           SPT    = "restaurant_invite"
+	RestaurantVerificationCode OTC    = "restaurant_verification_code"
+	RestaurantVerificationFail Record = "restaurant_verification_failures"
+	Session                    Record = "session"
+	TwoFactorChallenge         OAT    = "two_factor_challenge"
 )
 
 var (
-	AuthTokenDuration              = time.Hour
-	EmailVerificationTokenDuration = 24 * time.Hour
+	AuthTokenDuration                  = time.Hour
+	EmailChangeTokenDuration           = 24 * time.Hour
+	EmailVerificationTokenDuration     = 24 * time.Hour
+	OIDCStateDuration                  = 10 * time.Minute
+	OwnershipTransferTokenDuration     = 48 * time.Hour
+	PasswordResetTokenDuration         = time.Hour
+	RestaurantInviteTokenDuration      = 7 * 24 * time.Hour
+	RestaurantVerificationCodeDuration = 30 * time.Minute
+	TwoFactorChallengeDuration         = 5 * time.Minute
 )
@@ -9,12 +9,20 @@ import (
 
 type ContextKey string
 
+var ErrUserIDNotFound = errors.New("user ID not found in context")
+
 const (
 	UserIDContextKey       ContextKey = "userID"
 	AuthOATContextKey      ContextKey = "authOAT"
 	RestaurantIDContextKey ContextKey = "restaurantID"
 	RestaurantContextKey   ContextKey = "restaurant"
 	UserRoleIDContextKey   ContextKey = "userRoleID"
+	APIKeyIDContextKey     ContextKey = "apiKeyID"
+	APIKeyScopesContextKey ContextKey = "apiKeyScopes"
+	ClientIPContextKey     ContextKey = "clientIP"
+
+	OrganizationIDContextKey     ContextKey = "organizationID"
+	OrganizationRoleIDContextKey ContextKey = "organizationRoleID"
 )
 
 func GetValueFromContext(ctx context.Context, key ContextKey) (string, error) {
@@ -29,7 +37,7 @@ func GetValueFromContext(ctx context.Context, key ContextKey) (string, error) {
 func GetUserIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	val := ctx.Value(UserIDContextKey)
 	if val == nil {
-		return uuid.Nil, errors.New("user ID not found in context")
+		return uuid.Nil, ErrUserIDNotFound
 	}
 
 	return uuid.MustParse(val.(string)), nil
@@ -44,11 +52,48 @@ func GetRestaurantIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	return val.(uuid.UUID), nil
 }
 
-func GetUserRoleIDFromContext(ctx context.Context) (int16, error) {
+func GetUserRoleIDFromContext(ctx context.Context) (int32, error) {
 	val := ctx.Value(UserRoleIDContextKey)
 	if val == nil {
 		return 0, errors.New("user role ID not found in context")
 	}
 
-	return val.(int16), nil
+	return val.(int32), nil
+}
+
+// GetAPIKeyScopesFromContext returns the scopes of the API key that authenticated the request.
+func GetAPIKeyIDFromContext(ctx context.Context) (int, error) {
+	val := ctx.Value(APIKeyIDContextKey)
+	if val == nil {
+		return 0, errors.New("API key ID not found in context")
+	}
+
+	return val.(int), nil
+}
+
+func GetAPIKeyScopesFromContext(ctx context.Context) ([]string, error) {
+	val := ctx.Value(APIKeyScopesContextKey)
+	if val == nil {
+		return nil, errors.New("API key scopes not found in context")
+	}
+
+	return val.([]string), nil
+}
+
+func GetOrganizationIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	val := ctx.Value(OrganizationIDContextKey)
+	if val == nil {
+		return uuid.Nil, errors.New("organization ID not found in context")
+	}
+
+	return val.(uuid.UUID), nil
+}
+
+func GetOrganizationRoleIDFromContext(ctx context.Context) (int32, error) {
+	val := ctx.Value(OrganizationRoleIDContextKey)
+	if val == nil {
+		return 0, errors.New("organization role ID not found in context")
+	}
+
+	return val.(int32), nil
 }
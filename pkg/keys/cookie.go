@@ -3,4 +3,6 @@ package keys
 const (
 	AuthOATCookieName          = "go-session"
 	ActiveRestaurantCookieName = "active_restaurant"
+	CSRFCookieName             = "csrf_token"
+	OIDCStateCookieName        = "oidc_state"
 )
@@ -3,4 +3,6 @@ package keys
 const (
 	ActiveRestaurantHeaderName = "Active-Restaurant-ID"
 	AuthorizationHeaderName    = "Authorization"
+	APIKeyHeaderName           = "X-API-Key"
+	CSRFHeaderName             = "X-CSRF-Token"
 )
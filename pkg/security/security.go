@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"math/big"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -32,6 +33,19 @@ func GenerateRandomString(length int) (string, error) {
 	return base64.URLEncoding.EncodeToString(b), nil
 }
 
+func GenerateNumericCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("error during numeric code generation: %w", err)
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+
+	return string(code), nil
+}
+
 func SignString(data string, secretKey []byte) string {
 	h := hmac.New(sha256.New, secretKey)
 	h.Write([]byte(data))